@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/internal/lint"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	descriptorSet = flag.String("descriptor_set", "", "descriptor set file, as written by protoc --include_source_info -o")
+	config        = flag.String("config", "", "global configuration file")
+	outputJSON    = flag.String("diagnostics_json", "", "file where the diagnostics are written as JSON")
+	outputSARIF   = flag.String("diagnostics_sarif", "", "file where the diagnostics are written as SARIF")
+)
+
+func load(descriptorSet string, config string) (*descriptorpb.FileDescriptorSet, *validate.Runtime, *validate.Configuration, error) {
+	b, err := os.ReadFile(descriptorSet)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err = proto.Unmarshal(b, set); err != nil {
+		return nil, nil, nil, fmt.Errorf("descriptor set error: %w", err)
+	}
+	c := &validate.Configuration{}
+	if len(config) > 0 {
+		b, err := os.ReadFile(config)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if err := yaml.Unmarshal(b, &c); err != nil {
+			return nil, nil, nil, fmt.Errorf("config error: %w", err)
+		}
+	}
+	r, err := validate.NewRuntimeFromFileDescriptorSet(set)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return set, r, c, nil
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s -descriptor_set file [flags] [proto file...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *descriptorSet == "" {
+		flag.Usage()
+		os.Exit(2)
+	} else if *outputJSON != "" && *outputJSON == *outputSARIF {
+		fmt.Fprintln(os.Stderr, "-diagnostics_json and -diagnostics_sarif must be different files")
+		os.Exit(2)
+	}
+	set, r, c, err := load(*descriptorSet, *config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	// every file of the set is linted by default
+	paths := flag.Args()
+	if len(paths) == 0 {
+		for _, file := range set.File {
+			paths = append(paths, file.GetName())
+		}
+	}
+	var diagnostics []*lint.Diagnostic
+	for _, path := range paths {
+		var fd protoreflect.FileDescriptor
+		if fd, err = r.Files().FindFileByPath(path); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			os.Exit(2)
+		}
+		diagnostics = append(diagnostics, lint.Lint(fd, c)...)
+	}
+	for _, d := range diagnostics {
+		fmt.Println(d)
+	}
+	for path, format := range map[string]lint.Format{*outputJSON: lint.FormatJSON, *outputSARIF: lint.FormatSARIF} {
+		if path == "" {
+			continue
+		}
+		if err = lint.WriteFile(path, format, diagnostics); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+	if lint.HasErrors(diagnostics) {
+		os.Exit(1)
+	}
+}
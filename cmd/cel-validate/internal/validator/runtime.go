@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadRuntime builds a runtime from a descriptor set file, as written by
+// protoc -o, and an optional configuration file in the plugin format
+func LoadRuntime(descriptorSet string, config string) (*validate.Runtime, error) {
+	b, err := os.ReadFile(descriptorSet)
+	if err != nil {
+		return nil, err
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err = proto.Unmarshal(b, set); err != nil {
+		return nil, fmt.Errorf("descriptor set error: %w", err)
+	}
+	c := &validate.Configuration{}
+	if len(config) > 0 {
+		b, err := os.ReadFile(config)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(b, &c); err != nil {
+			return nil, fmt.Errorf("config error: %w", err)
+		}
+	}
+	return validate.NewRuntimeFromFileDescriptorSet(set, validate.WithConfiguration(c))
+}
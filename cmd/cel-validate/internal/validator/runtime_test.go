@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestLoadRuntime(t *testing.T) {
+	dir := t.TempDir()
+	raw, err := proto.Marshal(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(validate.File_testdata_validate_message_proto),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	descriptorSet := filepath.Join(dir, "set.pb")
+	if err = os.WriteFile(descriptorSet, raw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := filepath.Join(dir, "config.yml")
+	if err = os.WriteFile(config, []byte("rule:\n  messagerules:\n    testdata.validate.Message:\n      rule:\n        programs:\n        - expr: name != \"\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		Name          string
+		DescriptorSet string
+		Config        string
+		WantErr       bool
+		WantValidErr  bool
+	}{
+		{
+			Name:          "Missing descriptor set",
+			DescriptorSet: filepath.Join(dir, "missing.pb"),
+			WantErr:       true,
+		},
+		{
+			Name:          "Invalid descriptor set",
+			DescriptorSet: config,
+			WantErr:       true,
+		},
+		{
+			Name:          "OK",
+			DescriptorSet: descriptorSet,
+		},
+		{
+			Name:          "OK (config)",
+			DescriptorSet: descriptorSet,
+			Config:        config,
+			WantValidErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			r, err := LoadRuntime(tt.DescriptorSet, tt.Config)
+			if (tt.WantErr && err == nil) || (!tt.WantErr && err != nil) {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			} else if !tt.WantErr {
+				err = r.ValidateJSON(context.Background(), "testdata.validate.Message", []byte(`{}`))
+				if (tt.WantValidErr && err == nil) || (!tt.WantValidErr && err != nil) {
+					t.Errorf("wantValidErr %v, got %v", tt.WantValidErr, err)
+				}
+			}
+		})
+	}
+}
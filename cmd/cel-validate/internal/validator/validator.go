@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+type Format string
+
+const (
+	FormatJSON      Format = "json"
+	FormatNDJSON    Format = "ndjson"
+	FormatTextproto Format = "textproto"
+	FormatBinary    Format = "binary"
+	FormatDelimited Format = "delimited"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatJSON, FormatNDJSON, FormatTextproto, FormatBinary, FormatDelimited:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown format %q", s)
+	}
+}
+
+type Summary struct {
+	Records int
+	Invalid int
+}
+
+func (s *Summary) Add(other Summary) {
+	s.Records += other.Records
+	s.Invalid += other.Invalid
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("%d records, %d valid, %d invalid", s.Records, s.Records-s.Invalid, s.Invalid)
+}
+
+type Validator struct {
+	Runtime *validate.Runtime
+	Message string
+	Format  Format
+	Out     io.Writer
+}
+
+// Validate validates every record of the input, and prints the failing ones.
+// The error is only returned when the input cannot be read.
+func (v *Validator) Validate(ctx context.Context, name string, r io.Reader) (Summary, error) {
+	summary := Summary{}
+	err := v.records(r, func(record string, raw []byte) {
+		summary.Records++
+		if err := v.validate(ctx, raw); err != nil {
+			summary.Invalid++
+			if record != "" {
+				fmt.Fprintf(v.Out, "%s:%s: %v\n", name, record, err)
+			} else {
+				fmt.Fprintf(v.Out, "%s: %v\n", name, err)
+			}
+		}
+	})
+	return summary, err
+}
+
+func (v *Validator) records(r io.Reader, fn func(record string, raw []byte)) error {
+	switch v.Format {
+	case FormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+		for line := 1; scanner.Scan(); line++ {
+			if raw := bytes.TrimSpace(scanner.Bytes()); len(raw) > 0 {
+				fn(fmt.Sprint(line), raw)
+			}
+		}
+		return scanner.Err()
+	case FormatDelimited:
+		br := bufio.NewReader(r)
+		for record := 1; ; record++ {
+			size, err := binary.ReadUvarint(br)
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return fmt.Errorf("record %d: %w", record, err)
+			}
+			raw := make([]byte, size)
+			if _, err = io.ReadFull(br, raw); err != nil {
+				return fmt.Errorf("record %d: %w", record, err)
+			}
+			fn(fmt.Sprint(record), raw)
+		}
+	default:
+		raw, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		fn("", raw)
+		return nil
+	}
+}
+
+func (v *Validator) validate(ctx context.Context, raw []byte) error {
+	m, err := v.Runtime.NewMessage(v.Message)
+	if err != nil {
+		return err
+	}
+	switch v.Format {
+	case FormatJSON, FormatNDJSON:
+		err = protojson.UnmarshalOptions{Resolver: v.Runtime.TypeResolver()}.Unmarshal(raw, m)
+	case FormatTextproto:
+		err = prototext.UnmarshalOptions{Resolver: v.Runtime.TypeResolver()}.Unmarshal(raw, m)
+	default:
+		err = proto.UnmarshalOptions{Resolver: v.Runtime.TypeResolver()}.Unmarshal(raw, m)
+	}
+	if err != nil {
+		return fmt.Errorf("unmarshal error: %w", err)
+	}
+	return v.Runtime.Validate(ctx, m)
+}
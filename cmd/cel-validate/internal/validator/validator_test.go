@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	v "github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"google.golang.org/protobuf/proto"
+)
+
+func marshal(m proto.Message) string {
+	raw, err := proto.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+	return string(raw)
+}
+
+func delimited(msgs ...proto.Message) string {
+	b := []byte{}
+	for _, m := range msgs {
+		raw := marshal(m)
+		b = binary.AppendUvarint(b, uint64(len(raw)))
+		b = append(b, raw...)
+	}
+	return string(b)
+}
+
+func TestValidator(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Format      Format
+		Input       string
+		WantSummary Summary
+		WantOutput  string
+		WantErr     bool
+	}{
+		{
+			Name:        "JSON",
+			Format:      FormatJSON,
+			Input:       `{"name": "name"}`,
+			WantSummary: Summary{Records: 1},
+		},
+		{
+			Name:        "JSON failure",
+			Format:      FormatJSON,
+			Input:       `{"name": ""}`,
+			WantSummary: Summary{Records: 1, Invalid: 1},
+			WantOutput:  `input: validation failed on "testdata.validate.MessageExpr"` + "\n",
+		},
+		{
+			Name:        "NDJSON",
+			Format:      FormatNDJSON,
+			Input:       "{\"name\": \"name\"}\n\n{\"name\": \"\"}\n{\"unknown\": 1}\n",
+			WantSummary: Summary{Records: 3, Invalid: 2},
+			WantOutput:  "input:3: validation failed",
+		},
+		{
+			Name:        "Textproto",
+			Format:      FormatTextproto,
+			Input:       `name: "name"`,
+			WantSummary: Summary{Records: 1},
+		},
+		{
+			Name:        "Binary",
+			Format:      FormatBinary,
+			Input:       marshal(&validate.MessageExpr{Name: "name"}),
+			WantSummary: Summary{Records: 1},
+		},
+		{
+			Name:        "Delimited",
+			Format:      FormatDelimited,
+			Input:       delimited(&validate.MessageExpr{Name: "name"}, &validate.MessageExpr{}),
+			WantSummary: Summary{Records: 2, Invalid: 1},
+			WantOutput:  "input:2: validation failed",
+		},
+		{
+			Name:        "Delimited truncated",
+			Format:      FormatDelimited,
+			Input:       delimited(&validate.MessageExpr{Name: "name"})[:3],
+			WantSummary: Summary{},
+			WantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			validator := &Validator{Runtime: v.NewRuntime(), Message: "testdata.validate.MessageExpr", Format: tt.Format, Out: out}
+			summary, err := validator.Validate(context.Background(), "input", strings.NewReader(tt.Input))
+			if (tt.WantErr && err == nil) || (!tt.WantErr && err != nil) {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			}
+			if summary != tt.WantSummary {
+				t.Errorf("want %v, got %v", tt.WantSummary, summary)
+			}
+			if !strings.Contains(out.String(), tt.WantOutput) {
+				t.Errorf("want output %q, got %q", tt.WantOutput, out.String())
+			}
+		})
+	}
+}
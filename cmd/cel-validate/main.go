@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/cmd/cel-validate/internal/validator"
+)
+
+var (
+	descriptorSet = flag.String("descriptor_set", "", "descriptor set file, as written by protoc -o")
+	config        = flag.String("config", "", "global configuration file")
+	message       = flag.String("message", "", "full name of the message type")
+	format        = flag.String("format", string(validator.FormatJSON), "input format: json, ndjson, textproto, binary or delimited")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s -descriptor_set file -message name [flags] [file...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *descriptorSet == "" || *message == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	f, err := validator.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	r, err := validator.LoadRuntime(*descriptorSet, *config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	v := &validator.Validator{Runtime: r, Message: *message, Format: f, Out: os.Stdout}
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	summary := validator.Summary{}
+	for _, name := range files {
+		in := os.Stdin
+		if name != "-" {
+			if in, err = os.Open(name); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+		}
+		s, err := v.Validate(context.Background(), name, in)
+		in.Close()
+		summary.Add(s)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			os.Exit(2)
+		}
+	}
+	fmt.Println(summary)
+	if summary.Invalid > 0 {
+		os.Exit(1)
+	}
+}
@@ -1,17 +1,44 @@
 package plugin
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/nlachfr/protoc-gen-cel-validate/cmd/protoc-gen-go-cel-validate/internal/template"
 	"github.com/nlachfr/protoc-gen-cel-validate/validate"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
 )
 
-func NewFile(p *protogen.Plugin, f *protogen.File, c *validate.Configuration) (*File, error) {
+// CheckConfiguration checks the references of the configuration from every
+// file to generate, giving each distinct warning once to warn
+func CheckConfiguration(p *protogen.Plugin, c *validate.Configuration, warn func(err error), opts ...validate.ManagerOption) error {
+	warned := map[string]bool{}
+	opts = append(opts, validate.WithConfigurationWarnings(func(err error) {
+		if warn != nil && !warned[err.Error()] {
+			warned[err.Error()] = true
+			warn(err)
+		}
+	}))
+	for _, f := range p.Files {
+		if !f.Generate {
+			continue
+		}
+		manager, err := validate.NewUnregisteredManager(f.Desc, append([]validate.ManagerOption{validate.WithConfiguration(c)}, opts...)...)
+		if err != nil {
+			return err
+		} else if err = manager.CheckConfiguration(); err != nil {
+			return fmt.Errorf("%s: %w", f.Desc.Path(), err)
+		}
+	}
+	return nil
+}
+
+func NewFile(p *protogen.Plugin, f *protogen.File, c *validate.Configuration, opts ...validate.ManagerOption) (*File, error) {
 	g := p.NewGeneratedFile(f.GeneratedFilenamePrefix+".pb.cel.validate.go", f.GoImportPath)
 	cfg := &validate.Configuration{}
 	proto.Merge(cfg, c)
-	manager, err := validate.NewManager(f.Desc, validate.WithConfiguration(cfg))
+	manager, err := validate.NewUnregisteredManager(f.Desc, append([]validate.ManagerOption{validate.WithConfiguration(cfg)}, opts...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -30,6 +57,7 @@ func NewFile(p *protogen.Plugin, f *protogen.File, c *validate.Configuration) (*
 		Services: svcs,
 		Messages: msgs,
 		Config:   cfg,
+		manager:  manager,
 	}, nil
 }
 
@@ -40,6 +68,9 @@ type File struct {
 	Services []*Service
 	Messages []*Message
 	Config   *validate.Configuration
+	// ExamplesTest generates a _test.go file checking the examples
+	ExamplesTest bool
+	manager      *validate.Manager
 }
 
 func (f *File) Generate() error {
@@ -48,8 +79,17 @@ func (f *File) Generate() error {
 	}
 	if tmpl, err := template.GenerateTemplate(f.p.Request.CompilerVersion, f.g); err != nil {
 		return err
+	} else if err = tmpl.Execute(f.g, f); err != nil {
+		return err
+	}
+	if !f.ExamplesTest || len(f.manager.Examples()) == 0 {
+		return nil
+	}
+	g := f.p.NewGeneratedFile(f.GeneratedFilenamePrefix+".pb.cel.validate_test.go", f.GoImportPath)
+	if tmpl, err := template.GenerateTestTemplate(f.p.Request.CompilerVersion, g); err != nil {
+		return err
 	} else {
-		return tmpl.Execute(f.g, f)
+		return tmpl.Execute(g, f)
 	}
 }
 
@@ -64,7 +104,7 @@ func (f *File) Validate() error {
 			return err
 		}
 	}
-	return nil
+	return f.manager.CheckExamples(context.Background())
 }
 
 func NewService(b *validate.Manager, s *protogen.Service) *Service {
@@ -1,11 +1,20 @@
 package plugin
 
 import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/nlachfr/protoc-gen-cel-validate/testdata/cmd/plugin"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/pluginpb"
 )
 
@@ -167,9 +176,12 @@ func newFile(desc protoreflect.FileDescriptor) *protogen.File {
 
 func TestGenerate(t *testing.T) {
 	tests := []struct {
-		Name    string
-		Desc    []protoreflect.FileDescriptor
-		WantErr bool
+		Name         string
+		Desc         []protoreflect.FileDescriptor
+		Config       *validate.Configuration
+		Opts         []validate.ManagerOption
+		ExamplesTest bool
+		WantErr      bool
 	}{
 		{
 			Name:    "Basic",
@@ -206,6 +218,64 @@ func TestGenerate(t *testing.T) {
 			Desc:    []protoreflect.FileDescriptor{plugin.File_testdata_cmd_plugin_error_proto},
 			WantErr: true,
 		},
+		{
+			Name: "Unresolved configuration",
+			Desc: []protoreflect.FileDescriptor{plugin.File_testdata_cmd_plugin_basic_proto},
+			Config: &validate.Configuration{
+				Rule: &validate.FileRule{
+					MessageRules: map[string]*validate.MessageRule{
+						"testdata.cmd.plugin.BasicRequst": {},
+					},
+				},
+			},
+			WantErr: true,
+		},
+		{
+			Name: "Unresolved configuration (lenient)",
+			Desc: []protoreflect.FileDescriptor{plugin.File_testdata_cmd_plugin_basic_proto},
+			Config: &validate.Configuration{
+				Rule: &validate.FileRule{
+					MessageRules: map[string]*validate.MessageRule{
+						"testdata.cmd.plugin.BasicRequst": {},
+					},
+				},
+			},
+			Opts:    []validate.ManagerOption{validate.WithLenientConfiguration(nil)},
+			WantErr: false,
+		},
+		{
+			Name: "Examples",
+			Desc: []protoreflect.FileDescriptor{plugin.File_testdata_cmd_plugin_basic_proto},
+			Config: &validate.Configuration{
+				Rule: &validate.FileRule{
+					MessageRules: map[string]*validate.MessageRule{
+						"testdata.cmd.plugin.BasicRequest": {
+							FieldRules: map[string]*validate.FieldRule{
+								"name": {ValidExamples: []string{`"names/name"`}, InvalidExamples: []string{`"name"`}},
+							},
+						},
+					},
+				},
+			},
+			ExamplesTest: true,
+			WantErr:      false,
+		},
+		{
+			Name: "Examples not behaving as declared",
+			Desc: []protoreflect.FileDescriptor{plugin.File_testdata_cmd_plugin_basic_proto},
+			Config: &validate.Configuration{
+				Rule: &validate.FileRule{
+					MessageRules: map[string]*validate.MessageRule{
+						"testdata.cmd.plugin.BasicRequest": {
+							FieldRules: map[string]*validate.FieldRule{
+								"name": {ValidExamples: []string{`"name"`}},
+							},
+						},
+					},
+				},
+			},
+			WantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
@@ -215,16 +285,168 @@ func TestGenerate(t *testing.T) {
 				gs[i].GeneratedFilenamePrefix = t.TempDir()
 			}
 			var i int32 = 0
-			f, err := NewFile(&protogen.Plugin{Request: &pluginpb.CodeGeneratorRequest{CompilerVersion: &pluginpb.Version{
+			p := &protogen.Plugin{Request: &pluginpb.CodeGeneratorRequest{CompilerVersion: &pluginpb.Version{
 				Major: &i,
 				Minor: &i,
 				Patch: &i,
-			}}, Files: gs}, gs[0], nil)
-			if err != nil {
-				t.Errorf("cannot build file: %v", err)
-			} else if err = f.Generate(); err != nil != tt.WantErr {
+			}}, Files: gs}
+			gs[0].Generate = true
+			err := CheckConfiguration(p, tt.Config, nil, tt.Opts...)
+			var f *File
+			if err == nil {
+				f, err = NewFile(p, gs[0], tt.Config, tt.Opts...)
+			}
+			if err == nil {
+				f.ExamplesTest = tt.ExamplesTest
+				err = f.Generate()
+			}
+			if err != nil != tt.WantErr {
 				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
 			}
 		})
 	}
 }
+
+// appendFileProtos appends the file after its dependencies
+func appendFileProtos(fdps []*descriptorpb.FileDescriptorProto, desc protoreflect.FileDescriptor) []*descriptorpb.FileDescriptorProto {
+	for _, fdp := range fdps {
+		if fdp.GetName() == desc.Path() {
+			return fdps
+		}
+	}
+	for i := 0; i < desc.Imports().Len(); i++ {
+		fdps = appendFileProtos(fdps, desc.Imports().Get(i).FileDescriptor)
+	}
+	return append(fdps, protodesc.ToFileDescriptorProto(desc))
+}
+
+func TestGenerateExamplesTest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test on the generated code")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	desc := plugin.File_testdata_cmd_plugin_basic_proto
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate:  []string{desc.Path()},
+		Parameter:       proto.String("paths=source_relative"),
+		ProtoFile:       appendFileProtos(nil, desc),
+		CompilerVersion: &pluginpb.Version{Major: proto.Int32(3), Minor: proto.Int32(21), Patch: proto.Int32(0)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &validate.Configuration{Rule: &validate.FileRule{MessageRules: map[string]*validate.MessageRule{
+		"testdata.cmd.plugin.BasicRequest": {FieldRules: map[string]*validate.FieldRule{
+			"name": {ValidExamples: []string{`"names/name"`}, InvalidExamples: []string{`"name"`}},
+		}},
+	}}}
+	for _, file := range gen.Files {
+		if !file.Generate {
+			continue
+		}
+		f, err := NewFile(gen, file, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.ExamplesTest = true
+		if err = f.Generate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		t.Fatal(resp.GetError())
+	}
+	// the generated files are added to the package of the testdata with an
+	// overlay, and their tests run
+	root, err := filepath.Abs("../../../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	overlay := map[string]map[string]string{"Replace": {}}
+	for _, f := range resp.File {
+		path := filepath.Join(dir, filepath.Base(f.GetName()))
+		if err = os.WriteFile(path, []byte(f.GetContent()), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		overlay["Replace"][filepath.Join(root, f.GetName())] = path
+	}
+	if len(overlay["Replace"]) != 2 {
+		t.Fatalf("want the generated code and its test, got %d files", len(overlay["Replace"]))
+	}
+	b, err := json.Marshal(overlay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlayPath := filepath.Join(dir, "overlay.json")
+	if err = os.WriteFile(overlayPath, b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(goBin, "test", "-count=1", "-v", "-overlay", overlayPath, "-run", "ValidateExamples", "./testdata/cmd/plugin/")
+	cmd.Dir = root
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("generated test failed: %v\n%s", err, out)
+	} else if !strings.Contains(string(out), "--- PASS: TestFile_testdata_cmd_plugin_basic_proto_ValidateExamples") {
+		t.Errorf("generated test not run:\n%s", out)
+	}
+}
+
+func TestCheckConfiguration(t *testing.T) {
+	descs := []protoreflect.FileDescriptor{plugin.File_testdata_cmd_plugin_basic_proto, plugin.File_testdata_cmd_plugin_advanced_proto}
+	req := &pluginpb.CodeGeneratorRequest{Parameter: proto.String("paths=source_relative")}
+	for _, desc := range descs {
+		req.FileToGenerate = append(req.FileToGenerate, desc.Path())
+		req.ProtoFile = appendFileProtos(req.ProtoFile, desc)
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		Name      string
+		Config    *validate.Configuration
+		Opts      []validate.ManagerOption
+		WantWarns int
+		WantErr   bool
+	}{
+		{
+			Name: "Other package",
+			Config: &validate.Configuration{Rule: &validate.FileRule{MessageRules: map[string]*validate.MessageRule{
+				"other.Message": {},
+			}}},
+			WantWarns: 1,
+		},
+		{
+			Name: "Package of the files",
+			Config: &validate.Configuration{Rule: &validate.FileRule{MessageRules: map[string]*validate.MessageRule{
+				"testdata.cmd.plugin.BasicRequst": {},
+			}}},
+			WantErr: true,
+		},
+		{
+			Name: "Package of the files (lenient)",
+			Config: &validate.Configuration{Rule: &validate.FileRule{MessageRules: map[string]*validate.MessageRule{
+				"testdata.cmd.plugin.BasicRequst": {},
+			}}},
+			Opts:      []validate.ManagerOption{validate.WithLenientConfiguration(nil)},
+			WantWarns: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			warns := []error{}
+			err := CheckConfiguration(gen, tt.Config, func(err error) { warns = append(warns, err) }, tt.Opts...)
+			if err != nil != tt.WantErr {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			}
+			if len(warns) != tt.WantWarns {
+				t.Errorf("want %d warnings, got %v", tt.WantWarns, warns)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/internal/lint"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// Lint returns the diagnostics of the files to generate
+func Lint(p *protogen.Plugin, c *validate.Configuration) []*lint.Diagnostic {
+	var diagnostics []*lint.Diagnostic
+	for _, file := range p.Files {
+		if file.Generate {
+			diagnostics = append(diagnostics, lint.Lint(file.Desc, c)...)
+		}
+	}
+	return diagnostics
+}
+
+// WriteDiagnostics writes the diagnostics to the JSON and SARIF files whose
+// path is set. They are written directly, as the generated files are dropped
+// when the plugin fails.
+func WriteDiagnostics(jsonPath, sarifPath string, diagnostics []*lint.Diagnostic) error {
+	if jsonPath != "" && jsonPath == sarifPath {
+		return fmt.Errorf("diagnostics_json and diagnostics_sarif must be different files")
+	}
+	if jsonPath != "" {
+		if err := lint.WriteFile(jsonPath, lint.FormatJSON, diagnostics); err != nil {
+			return err
+		}
+	}
+	if sarifPath != "" {
+		if err := lint.WriteFile(sarifPath, lint.FormatSARIF, diagnostics); err != nil {
+			return err
+		}
+	}
+	return nil
+}
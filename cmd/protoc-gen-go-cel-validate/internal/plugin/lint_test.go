@@ -0,0 +1,47 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/internal/lint"
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/cmd/plugin"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestWriteDiagnostics(t *testing.T) {
+	desc := plugin.File_testdata_cmd_plugin_error_proto
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{desc.Path()},
+		Parameter:      proto.String("paths=source_relative"),
+		ProtoFile:      appendFileProtos(nil, desc),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diagnostics := Lint(gen, &validate.Configuration{})
+	if !lint.HasErrors(diagnostics) {
+		t.Fatalf("want lint errors, got %v", diagnostics)
+	}
+	dir := t.TempDir()
+	jsonPath, sarifPath := filepath.Join(dir, "cel.json"), filepath.Join(dir, "cel.sarif")
+	if err = WriteDiagnostics(jsonPath, sarifPath, diagnostics); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, path := range []string{jsonPath, sarifPath} {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("want %s written after a failing lint: %v", path, err)
+		} else if !strings.Contains(string(b), desc.Path()) {
+			t.Errorf("want the diagnostics of %s in %s, got %s", desc.Path(), path, b)
+		}
+	}
+	if err = WriteDiagnostics(jsonPath, jsonPath, diagnostics); err == nil {
+		t.Errorf("want an error for a shared output path")
+	}
+}
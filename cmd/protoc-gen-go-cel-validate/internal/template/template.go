@@ -14,7 +14,24 @@ import (
 //go:embed template.go.tmpl
 var tmpl string
 
+//go:embed template_test.go.tmpl
+var testTmpl string
+
 func GenerateTemplate(v *pluginpb.Version, g *protogen.GeneratedFile) (*template.Template, error) {
+	return newTemplate(v, g).Parse(tmpl)
+}
+
+// GenerateTestTemplate returns the template of the _test.go file checking the
+// examples of the rules
+func GenerateTestTemplate(v *pluginpb.Version, g *protogen.GeneratedFile) (*template.Template, error) {
+	return newTemplate(v, g).Funcs(template.FuncMap{
+		"testing": func(s string) string {
+			return g.QualifiedGoIdent(protogen.GoImportPath("testing").Ident(s))
+		},
+	}).Parse(testTmpl)
+}
+
+func newTemplate(v *pluginpb.Version, g *protogen.GeneratedFile) *template.Template {
 	return template.New("").Funcs(template.FuncMap{
 		"PluginVersion": func() string {
 			return fmt.Sprintf("v%d.%d.%d", version.Major, version.Minor, version.Patch)
@@ -59,5 +76,5 @@ func GenerateTemplate(v *pluginpb.Version, g *protogen.GeneratedFile) (*template
 			}
 			return nil
 		},
-	}).Parse(tmpl)
+	})
 }
@@ -2,9 +2,11 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
 
 	"github.com/nlachfr/protoc-gen-cel-validate/cmd/protoc-gen-go-cel-validate/internal/plugin"
+	"github.com/nlachfr/protoc-gen-cel-validate/internal/lint"
 	"github.com/nlachfr/protoc-gen-cel-validate/validate"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/reflect/protoregistry"
@@ -16,6 +18,12 @@ var (
 	stdlibOverridingEnabled          = flag.Bool("stdlib_overriding_enabled", false, "override stdlib when protobuf names conflict with cel")
 	requiredSupportDisabled          = flag.Bool("required_support_disabled", false, "disable google.protobuf.field_behavior.REQUIRED support")
 	resourceReferenceSupportDisabled = flag.Bool("resource_reference_support_disabled", false, "disable google.protobuf.resource_reference rules generation")
+	maxEstimatedCost                 = flag.Uint64("max_estimated_cost", 0, "maximum estimated cost of a program, unbounded if 0")
+	lintMode                         = flag.Bool("lint", false, "report the rule errors and warnings instead of generating code")
+	diagnosticsJSON                  = flag.String("diagnostics_json", "", "file where the rule errors and warnings are written as JSON")
+	diagnosticsSARIF                 = flag.String("diagnostics_sarif", "", "file where the rule errors and warnings are written as SARIF")
+	lenientConfig                    = flag.Bool("lenient_config", false, "warn instead of failing when the configuration references unknown services, methods, messages or fields")
+	examplesTest                     = flag.Bool("examples_test", false, "generate a _test.go file checking the valid and invalid examples of the rules")
 )
 
 func loadConfig(config string, c *validate.Configuration) error {
@@ -52,6 +60,8 @@ func main() {
 				c.RequiredSupportDisabled = *requiredSupportDisabled
 			case "resource_reference_support_disabled":
 				c.ResourceReferenceSupportDisabled = *resourceReferenceSupportDisabled
+			case "max_estimated_cost":
+				c.MaxEstimatedCost = *maxEstimatedCost
 			}
 		})
 		var files protoregistry.Files
@@ -60,13 +70,41 @@ func main() {
 				return err
 			}
 		}
+		opts := []validate.ManagerOption{validate.WithFiles(&files)}
+		if *lenientConfig {
+			opts = append(opts, validate.WithLenientConfiguration(nil))
+		}
+		if *lintMode || *diagnosticsJSON != "" || *diagnosticsSARIF != "" {
+			diagnostics := plugin.Lint(gen, c)
+			if err := plugin.WriteDiagnostics(*diagnosticsJSON, *diagnosticsSARIF, diagnostics); err != nil {
+				return err
+			}
+			if *lintMode {
+				for _, d := range diagnostics {
+					fmt.Fprintln(os.Stderr, d)
+				}
+				if lint.HasErrors(diagnostics) {
+					return fmt.Errorf("lint failed")
+				}
+				return nil
+			}
+		}
+		warn := func(err error) {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		}
+		if err := plugin.CheckConfiguration(gen, c, warn, opts...); err != nil {
+			return err
+		}
 		for _, file := range gen.Files {
 			if !file.Generate {
 				continue
 			}
-			if f, err := plugin.NewFile(gen, file, c); err != nil {
+			f, err := plugin.NewFile(gen, file, c, opts...)
+			if err != nil {
 				return err
-			} else if err = f.Generate(); err != nil {
+			}
+			f.ExamplesTest = *examplesTest
+			if err = f.Generate(); err != nil {
 				return err
 			}
 		}
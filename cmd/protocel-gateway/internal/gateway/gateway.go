@@ -0,0 +1,332 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate/errors"
+	"google.golang.org/genproto/googleapis/rpc/context/attribute_context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Option interface {
+	apply(g *Gateway)
+}
+
+type option func(g *Gateway)
+
+func (opt option) apply(g *Gateway) { opt(g) }
+
+// WithUnknownCallsAllowed forwards the calls which cannot be mapped to a
+// method instead of rejecting them
+func WithUnknownCallsAllowed() Option {
+	return option(func(g *Gateway) {
+		g.unknownCallsAllowed = true
+	})
+}
+
+// WithTransport sets the transport used for the upstream calls
+func WithTransport(transport http.RoundTripper) Option {
+	return option(func(g *Gateway) {
+		g.proxy.Transport = transport
+	})
+}
+
+// defaultMaxMessageSize is the size of the largest message read for the
+// validation, as for the gRPC servers
+const defaultMaxMessageSize = 4 << 20
+
+// WithMaxMessageSize sets the size of the largest request message, or HTTP
+// body, read for the validation. Larger requests are rejected.
+func WithMaxMessageSize(size int) Option {
+	return option(func(g *Gateway) {
+		g.maxMessageSize = size
+	})
+}
+
+// Gateway validates the gRPC, gRPC-Web and HTTP/JSON calls with the service
+// rules before forwarding them to the upstream
+type Gateway struct {
+	runtime             *validate.Runtime
+	proxy               *httputil.ReverseProxy
+	routes              []*httpRoute
+	unknownCallsAllowed bool
+	maxMessageSize      int
+}
+
+func New(runtime *validate.Runtime, upstream *url.URL, opts ...Option) (*Gateway, error) {
+	g := &Gateway{
+		runtime:        runtime,
+		proxy:          httputil.NewSingleHostReverseProxy(upstream),
+		maxMessageSize: defaultMaxMessageSize,
+	}
+	g.proxy.FlushInterval = -1
+	director := g.proxy.Director
+	g.proxy.Director = func(r *http.Request) {
+		director(r)
+		r.Host = upstream.Host
+	}
+	for _, opt := range opts {
+		opt.apply(g)
+	}
+	var err error
+	runtime.Files().RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for i := 0; i < fd.Services().Len() && err == nil; i++ {
+			methods := fd.Services().Get(i).Methods()
+			for j := 0; j < methods.Len() && err == nil; j++ {
+				var routes []*httpRoute
+				if routes, err = buildHTTPRoutes(methods.Get(j)); err == nil {
+					g.routes = append(g.routes, routes...)
+				}
+			}
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "application/grpc") {
+		g.serveGRPC(w, r, contentType)
+	} else {
+		g.serveHTTP(w, r)
+	}
+}
+
+func (g *Gateway) serveGRPC(w http.ResponseWriter, r *http.Request, contentType string) {
+	protocol := "grpc"
+	if strings.HasPrefix(contentType, "application/grpc-web") {
+		protocol = "grpc-web"
+	}
+	method, err := g.findMethod(r.URL.Path)
+	if err != nil {
+		if g.unknownCallsAllowed {
+			g.proxy.ServeHTTP(w, r)
+		} else {
+			writeGRPCError(w, contentType, status.New(codes.Unimplemented, err.Error()))
+		}
+		return
+	}
+	// only the first message of a stream is read and validated, the
+	// consumed bytes are forwarded with the rest of the body
+	consumed := &bytes.Buffer{}
+	var body io.Reader = io.TeeReader(r.Body, consumed)
+	if strings.HasPrefix(contentType, "application/grpc-web-text") {
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	m, err := g.runtime.NewMessage(string(method.Input().FullName()))
+	if err != nil {
+		writeGRPCError(w, contentType, status.New(codes.Internal, err.Error()))
+		return
+	}
+	header := make([]byte, 5)
+	if _, err = io.ReadFull(body, header); err != nil && err != io.EOF {
+		writeGRPCError(w, contentType, status.New(codes.InvalidArgument, err.Error()))
+		return
+	} else if err == nil {
+		if header[0] != 0 {
+			writeGRPCError(w, contentType, status.New(codes.Unimplemented, "compressed requests are not supported"))
+			return
+		}
+		size := binary.BigEndian.Uint32(header[1:])
+		if uint64(size) > uint64(g.maxMessageSize) {
+			writeGRPCError(w, contentType, status.Newf(codes.ResourceExhausted, "received message larger than max (%d vs. %d)", size, g.maxMessageSize))
+			return
+		}
+		raw := make([]byte, size)
+		if _, err = io.ReadFull(body, raw); err != nil {
+			writeGRPCError(w, contentType, status.New(codes.InvalidArgument, err.Error()))
+			return
+		} else if err = (proto.UnmarshalOptions{Resolver: g.runtime.TypeResolver()}).Unmarshal(raw, m); err != nil {
+			writeGRPCError(w, contentType, status.New(codes.InvalidArgument, err.Error()))
+			return
+		}
+	}
+	r.Body = readCloser{Reader: io.MultiReader(consumed, r.Body), Closer: r.Body}
+	if err = g.validate(r, protocol, method, m); err != nil {
+		writeGRPCError(w, contentType, statusFromError(err))
+		return
+	}
+	g.proxy.ServeHTTP(w, r)
+}
+
+func (g *Gateway) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	for _, route := range g.routes {
+		if route.verb != r.Method {
+			continue
+		}
+		vars, ok := route.template.match(r.URL.Path)
+		if !ok {
+			continue
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, int64(g.maxMessageSize)))
+		if _, ok := err.(*http.MaxBytesError); ok {
+			writeHTTPError(w, status.Newf(codes.ResourceExhausted, "received body larger than max (%d)", g.maxMessageSize))
+			return
+		} else if err != nil {
+			writeHTTPError(w, status.New(codes.InvalidArgument, err.Error()))
+			return
+		}
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		m, err := g.runtime.NewMessage(string(route.method.Input().FullName()))
+		if err != nil {
+			writeHTTPError(w, status.New(codes.Internal, err.Error()))
+			return
+		}
+		if err = route.buildRequest(m, body, r.URL.Query(), vars, g.runtime.TypeResolver()); err != nil {
+			writeHTTPError(w, status.New(codes.InvalidArgument, err.Error()))
+			return
+		}
+		if err = g.validate(r, "http", route.method, m); err != nil {
+			writeHTTPError(w, statusFromError(err))
+			return
+		}
+		g.proxy.ServeHTTP(w, r)
+		return
+	}
+	if g.unknownCallsAllowed {
+		g.proxy.ServeHTTP(w, r)
+	} else {
+		writeHTTPError(w, status.New(codes.NotFound, fmt.Sprintf("no method bound to %s %s", r.Method, r.URL.Path)))
+	}
+}
+
+func (g *Gateway) findMethod(path string) (protoreflect.MethodDescriptor, error) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("malformed method name %q", path)
+	}
+	d, err := g.runtime.Files().FindDescriptorByName(protoreflect.FullName(parts[0]))
+	if err != nil {
+		return nil, fmt.Errorf("unknown service %s", parts[0])
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("unknown service %s", parts[0])
+	}
+	md := sd.Methods().ByName(protoreflect.Name(parts[1]))
+	if md == nil {
+		return nil, fmt.Errorf("unknown method %s for service %s", parts[1], parts[0])
+	}
+	return md, nil
+}
+
+func (g *Gateway) validate(r *http.Request, protocol string, method protoreflect.MethodDescriptor, m proto.Message) error {
+	validater, err := g.runtime.GetServiceRuleValidater(method.Parent().(protoreflect.ServiceDescriptor))
+	if err != nil {
+		return err
+	}
+	return validater.Validate(r.Context(), newAttributeContext(r, protocol, method), m)
+}
+
+func newAttributeContext(r *http.Request, protocol string, method protoreflect.MethodDescriptor) *attribute_context.AttributeContext {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	attr := &attribute_context.AttributeContext{
+		Api: &attribute_context.AttributeContext_Api{
+			Service:   string(method.Parent().FullName()),
+			Operation: string(method.FullName()),
+			Protocol:  protocol,
+		},
+		Request: &attribute_context.AttributeContext_Request{
+			Method:   r.Method,
+			Headers:  map[string]string{},
+			Path:     r.URL.Path,
+			Host:     r.Host,
+			Scheme:   scheme,
+			Query:    r.URL.RawQuery,
+			Time:     timestamppb.Now(),
+			Size:     r.ContentLength,
+			Protocol: r.Proto,
+		},
+	}
+	for k, v := range r.Header {
+		attr.Request.Headers[strings.ToLower(k)] = strings.Join(v, ", ")
+	}
+	if host, port, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		pr := &attribute_context.AttributeContext_Peer{Ip: host}
+		pr.Port, _ = strconv.ParseInt(port, 10, 64)
+		attr.Origin = pr
+		attr.Source = pr
+	}
+	return attr
+}
+
+func statusFromError(err error) *status.Status {
+	if vErr, ok := err.(errors.ValidateError); ok {
+		switch vErr.GetKind() {
+		case errors.KindCostLimitExceeded:
+			return status.New(codes.ResourceExhausted, err.Error())
+		case errors.KindInterrupted:
+			return status.New(codes.Canceled, err.Error())
+		}
+		return status.New(codes.InvalidArgument, err.Error())
+	}
+	return status.New(codes.Internal, err.Error())
+}
+
+func writeGRPCError(w http.ResponseWriter, contentType string, s *status.Status) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Grpc-Status", strconv.Itoa(int(s.Code())))
+	w.Header().Set("Grpc-Message", encodeGRPCMessage(s.Message()))
+	w.WriteHeader(http.StatusOK)
+}
+
+// encodeGRPCMessage percent-encodes the message as required for the
+// grpc-message header
+func encodeGRPCMessage(msg string) string {
+	sb := strings.Builder{}
+	for i := 0; i < len(msg); i++ {
+		if c := msg[i]; c < ' ' || c > '~' || c == '%' {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		} else {
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+func writeHTTPError(w http.ResponseWriter, s *status.Status) {
+	code := http.StatusInternalServerError
+	switch s.Code() {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.ResourceExhausted:
+		code = http.StatusTooManyRequests
+	case codes.Canceled:
+		code = 499
+	}
+	b, _ := protojson.Marshal(s.Proto())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(b)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
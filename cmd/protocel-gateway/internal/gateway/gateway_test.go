@@ -0,0 +1,277 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	testdata "github.com/nlachfr/protoc-gen-cel-validate/testdata/cmd/gateway"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func newRuntime(t *testing.T) *validate.Runtime {
+	r, err := validate.NewRuntimeFromFileDescriptorSet(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(testdata.File_testdata_cmd_gateway_gateway_proto),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestGatewayGRPC(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	upstream := grpc.NewServer(grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+		if err := stream.RecvMsg(&emptypb.Empty{}); err != nil {
+			return err
+		}
+		return stream.SendMsg(&testdata.Book{Name: "upstream"})
+	}))
+	go upstream.Serve(lis)
+	defer upstream.Stop()
+	g, err := New(newRuntime(t), &url.URL{Scheme: "http", Host: lis.Addr().String()}, WithTransport(&http2.Transport{
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+			return net.Dial(network, addr)
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(h2c.NewHandler(g, &http2.Server{}))
+	defer server.Close()
+	conn, err := grpc.Dial(strings.TrimPrefix(server.URL, "http://"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	tests := []struct {
+		Name     string
+		Method   string
+		Request  interface{}
+		Metadata metadata.MD
+		WantCode codes.Code
+	}{
+		{
+			Name:     "Unknown method",
+			Method:   "/testdata.gateway.Library/DeleteBook",
+			Request:  &testdata.GetBookRequest{},
+			Metadata: metadata.Pairs("x-api-key", "key"),
+			WantCode: codes.Unimplemented,
+		},
+		{
+			Name:     "Service rule failure",
+			Method:   "/testdata.gateway.Library/GetBook",
+			Request:  &testdata.GetBookRequest{Name: "shelves/1/books/1"},
+			WantCode: codes.InvalidArgument,
+		},
+		{
+			Name:     "Method rule failure",
+			Method:   "/testdata.gateway.Library/GetBook",
+			Request:  &testdata.GetBookRequest{Name: "shelves/2/books/1"},
+			Metadata: metadata.Pairs("x-api-key", "key"),
+			WantCode: codes.InvalidArgument,
+		},
+		{
+			Name:     "OK",
+			Method:   "/testdata.gateway.Library/GetBook",
+			Request:  &testdata.GetBookRequest{Name: "shelves/1/books/1"},
+			Metadata: metadata.Pairs("x-api-key", "key"),
+			WantCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(context.Background(), tt.Metadata)
+			resp := &testdata.Book{}
+			err := conn.Invoke(ctx, tt.Method, tt.Request, resp)
+			if code := status.Code(err); code != tt.WantCode {
+				t.Errorf("want %v, got %v", tt.WantCode, err)
+			} else if err == nil && resp.Name != "upstream" {
+				t.Errorf("want upstream response, got %v", resp)
+			}
+		})
+	}
+}
+
+func TestGatewayGRPCMessageTooLarge(t *testing.T) {
+	g, err := New(newRuntime(t), &url.URL{Scheme: "http", Host: "127.0.0.1:0"}, WithMaxMessageSize(16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		Name   string
+		Header []byte
+	}{
+		{
+			Name:   "Over the limit",
+			Header: []byte{0, 0, 0, 0, 17},
+		},
+		{
+			Name:   "Huge length prefix",
+			Header: []byte{0, 0xff, 0xff, 0xff, 0xff},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/testdata.gateway.Library/GetBook", bytes.NewReader(tt.Header))
+			req.Header.Set("Content-Type", "application/grpc")
+			rec := httptest.NewRecorder()
+			g.ServeHTTP(rec, req)
+			if code := rec.Header().Get("Grpc-Status"); code != strconv.Itoa(int(codes.ResourceExhausted)) {
+				t.Errorf("want %v, got %s: %s", codes.ResourceExhausted, code, rec.Header().Get("Grpc-Message"))
+			}
+		})
+	}
+}
+
+func TestGatewayHTTP(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Write(append([]byte("upstream "), body...))
+	}))
+	defer upstream.Close()
+	u, _ := url.Parse(upstream.URL)
+	tests := []struct {
+		Name       string
+		Method     string
+		Path       string
+		Body       string
+		Headers    map[string]string
+		Opts       []Option
+		WantStatus int
+		WantBody   string
+	}{
+		{
+			Name:       "Unknown route",
+			Method:     http.MethodGet,
+			Path:       "/v1/unknown",
+			WantStatus: http.StatusNotFound,
+		},
+		{
+			Name:       "Unknown route allowed",
+			Method:     http.MethodGet,
+			Path:       "/v1/unknown",
+			Opts:       []Option{WithUnknownCallsAllowed()},
+			WantStatus: http.StatusOK,
+		},
+		{
+			Name:       "Service rule failure",
+			Method:     http.MethodGet,
+			Path:       "/v1/shelves/1/books/1",
+			WantStatus: http.StatusBadRequest,
+		},
+		{
+			Name:       "Path variable failure",
+			Method:     http.MethodGet,
+			Path:       "/v1/shelves/2/books/1",
+			Headers:    map[string]string{"X-Api-Key": "key"},
+			WantStatus: http.StatusBadRequest,
+		},
+		{
+			Name:       "Path variable",
+			Method:     http.MethodGet,
+			Path:       "/v1/shelves/1/books/1",
+			Headers:    map[string]string{"X-Api-Key": "key"},
+			WantStatus: http.StatusOK,
+		},
+		{
+			Name:       "Body field failure",
+			Method:     http.MethodPost,
+			Path:       "/v1/shelves/1/books",
+			Body:       `{"title": ""}`,
+			Headers:    map[string]string{"X-Api-Key": "key"},
+			WantStatus: http.StatusBadRequest,
+		},
+		{
+			Name:       "Body field",
+			Method:     http.MethodPost,
+			Path:       "/v1/shelves/1/books",
+			Body:       `{"title": "title"}`,
+			Headers:    map[string]string{"X-Api-Key": "key"},
+			WantStatus: http.StatusOK,
+			WantBody:   `upstream {"title": "title"}`,
+		},
+		{
+			Name:       "Invalid body",
+			Method:     http.MethodPost,
+			Path:       "/v1/shelves/1/books",
+			Body:       `{"title": 1}`,
+			Headers:    map[string]string{"X-Api-Key": "key"},
+			WantStatus: http.StatusBadRequest,
+		},
+		{
+			Name:       "Query parameter failure",
+			Method:     http.MethodGet,
+			Path:       "/v1/shelves/1/books?pageSize=20",
+			Headers:    map[string]string{"X-Api-Key": "key"},
+			WantStatus: http.StatusBadRequest,
+		},
+		{
+			Name:       "Query parameter",
+			Method:     http.MethodGet,
+			Path:       "/v1/shelves/1/books?page_size=5",
+			Headers:    map[string]string{"X-Api-Key": "key"},
+			WantStatus: http.StatusOK,
+		},
+		{
+			Name:       "Body too large",
+			Method:     http.MethodPost,
+			Path:       "/v1/shelves/1/books",
+			Body:       `{"title": "title"}`,
+			Headers:    map[string]string{"X-Api-Key": "key"},
+			Opts:       []Option{WithMaxMessageSize(8)},
+			WantStatus: http.StatusTooManyRequests,
+		},
+		{
+			Name:       "Additional binding failure",
+			Method:     http.MethodPost,
+			Path:       "/v1/shelves/1/books:list",
+			Body:       `{"pageSize": 20}`,
+			Headers:    map[string]string{"X-Api-Key": "key"},
+			WantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			g, err := New(newRuntime(t), u, tt.Opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest(tt.Method, tt.Path, strings.NewReader(tt.Body))
+			for k, v := range tt.Headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			g.ServeHTTP(rec, req)
+			if rec.Code != tt.WantStatus {
+				t.Errorf("want %d, got %d: %s", tt.WantStatus, rec.Code, rec.Body.String())
+			} else if tt.WantBody != "" && rec.Body.String() != tt.WantBody {
+				t.Errorf("want %q, got %q", tt.WantBody, rec.Body.String())
+			}
+		})
+	}
+}
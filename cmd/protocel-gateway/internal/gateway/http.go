@@ -0,0 +1,176 @@
+package gateway
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// httpRoute is a google.api.http binding of a method
+type httpRoute struct {
+	method   protoreflect.MethodDescriptor
+	verb     string
+	template *pathTemplate
+	body     string
+}
+
+func buildHTTPRoutes(method protoreflect.MethodDescriptor) ([]*httpRoute, error) {
+	rule := validate.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+	if rule == nil {
+		return nil, nil
+	}
+	routes := []*httpRoute{}
+	for _, r := range append([]*annotations.HttpRule{rule}, rule.AdditionalBindings...) {
+		var verb, path string
+		switch p := r.Pattern.(type) {
+		case *annotations.HttpRule_Get:
+			verb, path = http.MethodGet, p.Get
+		case *annotations.HttpRule_Put:
+			verb, path = http.MethodPut, p.Put
+		case *annotations.HttpRule_Post:
+			verb, path = http.MethodPost, p.Post
+		case *annotations.HttpRule_Delete:
+			verb, path = http.MethodDelete, p.Delete
+		case *annotations.HttpRule_Patch:
+			verb, path = http.MethodPatch, p.Patch
+		case *annotations.HttpRule_Custom:
+			verb, path = p.Custom.GetKind(), p.Custom.GetPath()
+		default:
+			continue
+		}
+		template, err := parsePathTemplate(path)
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", method.FullName(), err)
+		}
+		routes = append(routes, &httpRoute{method: method, verb: verb, template: template, body: r.Body})
+	}
+	return routes, nil
+}
+
+// buildRequest fills the request message from the body, the path variables
+// and the query parameters, following the google.api.http mapping
+func (r *httpRoute) buildRequest(m proto.Message, body []byte, query url.Values, vars map[string]string, resolver validate.TypeResolver) error {
+	if r.body != "" {
+		raw := body
+		if r.body != "*" {
+			fd := m.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(r.body))
+			if fd == nil {
+				return fmt.Errorf("unknown body field %q", r.body)
+			}
+			var err error
+			if raw, err = json.Marshal(map[string]json.RawMessage{fd.JSONName(): raw}); err != nil {
+				return err
+			}
+		}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true, Resolver: resolver}).Unmarshal(raw, m); err != nil {
+			return err
+		}
+	}
+	for field, value := range vars {
+		if err := setField(m.ProtoReflect(), field, []string{value}); err != nil {
+			return err
+		}
+	}
+	if r.body != "*" {
+		for field, values := range query {
+			if _, ok := vars[field]; ok || field == r.body || strings.HasPrefix(field, r.body+".") {
+				continue
+			}
+			if err := setField(m.ProtoReflect(), field, values); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func setField(m protoreflect.Message, path string, values []string) error {
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(part))
+		if fd == nil {
+			fd = m.Descriptor().Fields().ByJSONName(part)
+		}
+		if fd == nil {
+			return fmt.Errorf("unknown field %q", path)
+		}
+		if i < len(parts)-1 {
+			if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+				return fmt.Errorf("field %q is not a message", path)
+			}
+			m = m.Mutable(fd).Message()
+			continue
+		}
+		if fd.IsList() {
+			list := m.Mutable(fd).List()
+			for _, value := range values {
+				v, err := parseScalar(fd, value)
+				if err != nil {
+					return fmt.Errorf("field %q: %w", path, err)
+				}
+				list.Append(v)
+			}
+		} else if fd.IsMap() {
+			return fmt.Errorf("field %q: map fields are not supported", path)
+		} else if len(values) > 0 {
+			v, err := parseScalar(fd, values[len(values)-1])
+			if err != nil {
+				return fmt.Errorf("field %q: %w", path, err)
+			}
+			m.Set(fd, v)
+		}
+	}
+	return nil
+}
+
+func parseScalar(fd protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			b, err = base64.URLEncoding.DecodeString(s)
+		}
+		return protoreflect.ValueOfBytes(b), err
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(i)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(i), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		i, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(i)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		i, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(i), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.EnumKind:
+		if v := fd.Enum().Values().ByName(protoreflect.Name(s)); v != nil {
+			return protoreflect.ValueOfEnum(v.Number()), nil
+		}
+		i, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(i)), err
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported kind %s", fd.Kind())
+	}
+}
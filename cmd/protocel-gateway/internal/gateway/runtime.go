@@ -0,0 +1,45 @@
+package gateway
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadRuntime builds a runtime from descriptor set files, as written by
+// protoc -o, and an optional configuration file in the plugin format
+func LoadRuntime(descriptorSets []string, config string) (*validate.Runtime, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	loaded := map[string]bool{}
+	for _, descriptorSet := range descriptorSets {
+		b, err := os.ReadFile(descriptorSet)
+		if err != nil {
+			return nil, err
+		}
+		s := &descriptorpb.FileDescriptorSet{}
+		if err = proto.Unmarshal(b, s); err != nil {
+			return nil, fmt.Errorf("descriptor set %s error: %w", descriptorSet, err)
+		}
+		for _, fdp := range s.File {
+			if !loaded[fdp.GetName()] {
+				loaded[fdp.GetName()] = true
+				set.File = append(set.File, fdp)
+			}
+		}
+	}
+	c := &validate.Configuration{}
+	if len(config) > 0 {
+		b, err := os.ReadFile(config)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(b, &c); err != nil {
+			return nil, fmt.Errorf("config error: %w", err)
+		}
+	}
+	return validate.NewRuntimeFromFileDescriptorSet(set, validate.WithConfiguration(c))
+}
@@ -0,0 +1,139 @@
+package gateway
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type segmentKind int
+
+const (
+	segmentLiteral segmentKind = iota
+	segmentWildcard
+	segmentMultiWildcard
+)
+
+type pathSegment struct {
+	kind    segmentKind
+	literal string
+}
+
+type pathVariable struct {
+	field      string
+	start, end int
+}
+
+// pathTemplate is a google.api.http path template, such as
+// /v1/{name=shelves/*/books/*}:verb
+type pathTemplate struct {
+	segments  []pathSegment
+	variables []pathVariable
+	verb      string
+}
+
+func parsePathTemplate(s string) (*pathTemplate, error) {
+	if !strings.HasPrefix(s, "/") {
+		return nil, fmt.Errorf("path template %q: missing leading /", s)
+	}
+	s = s[1:]
+	t := &pathTemplate{}
+	if i := strings.LastIndex(s, ":"); i > strings.LastIndex(s, "/") && i > strings.LastIndex(s, "}") {
+		s, t.verb = s[:i], s[i+1:]
+	}
+	for len(s) > 0 {
+		if s[0] == '{' {
+			end := strings.Index(s, "}")
+			if end < 0 {
+				return nil, fmt.Errorf("path template %q: unterminated variable", s)
+			}
+			field, pattern := s[1:end], "*"
+			if i := strings.Index(field, "="); i >= 0 {
+				field, pattern = field[:i], field[i+1:]
+			}
+			v := pathVariable{field: field, start: len(t.segments)}
+			for _, p := range strings.Split(pattern, "/") {
+				t.segments = append(t.segments, newPathSegment(p))
+			}
+			v.end = len(t.segments)
+			t.variables = append(t.variables, v)
+			s = s[end+1:]
+		} else {
+			end := strings.Index(s, "/")
+			if end < 0 {
+				end = len(s)
+			}
+			t.segments = append(t.segments, newPathSegment(s[:end]))
+			s = s[end:]
+		}
+		if strings.HasPrefix(s, "/") {
+			s = s[1:]
+		} else if len(s) > 0 {
+			return nil, fmt.Errorf("path template %q: unexpected %q", s, s[0])
+		}
+	}
+	for i, seg := range t.segments {
+		if seg.kind == segmentMultiWildcard && i != len(t.segments)-1 {
+			return nil, fmt.Errorf("path template: ** must be the last segment")
+		}
+	}
+	return t, nil
+}
+
+func newPathSegment(s string) pathSegment {
+	switch s {
+	case "*":
+		return pathSegment{kind: segmentWildcard}
+	case "**":
+		return pathSegment{kind: segmentMultiWildcard}
+	default:
+		return pathSegment{kind: segmentLiteral, literal: s}
+	}
+}
+
+// match returns the values of the variables when the path matches the template
+func (t *pathTemplate) match(path string) (map[string]string, bool) {
+	path = strings.TrimPrefix(path, "/")
+	if t.verb != "" {
+		if !strings.HasSuffix(path, ":"+t.verb) {
+			return nil, false
+		}
+		path = strings.TrimSuffix(path, ":"+t.verb)
+	}
+	parts := strings.Split(path, "/")
+	ends := make([]int, len(t.segments))
+	i := 0
+	for j, seg := range t.segments {
+		switch seg.kind {
+		case segmentLiteral:
+			if i >= len(parts) || parts[i] != seg.literal {
+				return nil, false
+			}
+			i++
+		case segmentWildcard:
+			if i >= len(parts) || parts[i] == "" {
+				return nil, false
+			}
+			i++
+		case segmentMultiWildcard:
+			i = len(parts)
+		}
+		ends[j] = i
+	}
+	if i != len(parts) {
+		return nil, false
+	}
+	values := map[string]string{}
+	for _, v := range t.variables {
+		start := 0
+		if v.start > 0 {
+			start = ends[v.start-1]
+		}
+		value := strings.Join(parts[start:ends[v.end-1]], "/")
+		if unescaped, err := url.PathUnescape(value); err == nil {
+			value = unescaped
+		}
+		values[v.field] = value
+	}
+	return values, true
+}
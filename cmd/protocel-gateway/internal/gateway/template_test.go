@@ -0,0 +1,98 @@
+package gateway
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPathTemplate(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Template  string
+		Path      string
+		WantErr   bool
+		WantMatch bool
+		WantVars  map[string]string
+	}{
+		{
+			Name:     "Missing leading slash",
+			Template: "v1/books",
+			WantErr:  true,
+		},
+		{
+			Name:     "Unterminated variable",
+			Template: "/v1/{name=books/*",
+			WantErr:  true,
+		},
+		{
+			Name:     "Multi wildcard not last",
+			Template: "/v1/**/books",
+			WantErr:  true,
+		},
+		{
+			Name:      "Literal",
+			Template:  "/v1/books",
+			Path:      "/v1/books",
+			WantMatch: true,
+			WantVars:  map[string]string{},
+		},
+		{
+			Name:     "Literal mismatch",
+			Template: "/v1/books",
+			Path:     "/v1/shelves",
+		},
+		{
+			Name:      "Variable",
+			Template:  "/v1/{name=shelves/*/books/*}",
+			Path:      "/v1/shelves/1/books/2",
+			WantMatch: true,
+			WantVars:  map[string]string{"name": "shelves/1/books/2"},
+		},
+		{
+			Name:     "Variable too short",
+			Template: "/v1/{name=shelves/*/books/*}",
+			Path:     "/v1/shelves/1/books",
+		},
+		{
+			Name:      "Variables",
+			Template:  "/v1/shelves/{shelf}/books/{book.id}",
+			Path:      "/v1/shelves/1/books/a%20b",
+			WantMatch: true,
+			WantVars:  map[string]string{"shelf": "1", "book.id": "a b"},
+		},
+		{
+			Name:      "Multi wildcard",
+			Template:  "/v1/{name=files/**}",
+			Path:      "/v1/files/a/b/c",
+			WantMatch: true,
+			WantVars:  map[string]string{"name": "files/a/b/c"},
+		},
+		{
+			Name:      "Verb",
+			Template:  "/v1/{parent=shelves/*}/books:list",
+			Path:      "/v1/shelves/1/books:list",
+			WantMatch: true,
+			WantVars:  map[string]string{"parent": "shelves/1"},
+		},
+		{
+			Name:     "Verb mismatch",
+			Template: "/v1/{parent=shelves/*}/books:list",
+			Path:     "/v1/shelves/1/books",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			template, err := parsePathTemplate(tt.Template)
+			if (tt.WantErr && err == nil) || (!tt.WantErr && err != nil) {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			} else if !tt.WantErr {
+				vars, ok := template.match(tt.Path)
+				if ok != tt.WantMatch {
+					t.Errorf("wantMatch %v, got %v", tt.WantMatch, ok)
+				} else if !reflect.DeepEqual(vars, tt.WantVars) {
+					t.Errorf("want %v, got %v", tt.WantVars, vars)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/cmd/protocel-gateway/internal/gateway"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+var (
+	listen         = flag.String("listen", ":8080", "listening address")
+	upstream       = flag.String("upstream", "", "upstream url, using the h2c scheme for cleartext HTTP/2 (e.g. gRPC servers)")
+	descriptorSets = flag.String("descriptor_sets", "", "comma separated descriptor set files, as written by protoc -o")
+	config         = flag.String("config", "", "global configuration file")
+	allowUnknown   = flag.Bool("allow_unknown", false, "forward the calls which cannot be mapped to a method")
+	maxMessageSize = flag.Int("max_message_size", 4<<20, "size in bytes of the largest request message or HTTP body")
+)
+
+func main() {
+	flag.Parse()
+	if *upstream == "" || *descriptorSets == "" {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s -upstream url -descriptor_sets files [flags]\n", os.Args[0])
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	u, err := url.Parse(*upstream)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts := []gateway.Option{}
+	if u.Scheme == "h2c" {
+		u.Scheme = "http"
+		opts = append(opts, gateway.WithTransport(&http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		}))
+	}
+	if *allowUnknown {
+		opts = append(opts, gateway.WithUnknownCallsAllowed())
+	}
+	opts = append(opts, gateway.WithMaxMessageSize(*maxMessageSize))
+	r, err := gateway.LoadRuntime(strings.Split(*descriptorSets, ","), *config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	g, err := gateway.New(r, u, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Fatal(http.ListenAndServe(*listen, h2c.NewHandler(g, &http2.Server{})))
+}
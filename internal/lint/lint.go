@@ -0,0 +1,475 @@
+// Package lint builds the rules of a proto file and reports the errors and the
+// suspicious declarations with their location in the proto source.
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/cel-go/common"
+	"github.com/google/cel-go/parser"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// checks reported by the linter
+const (
+	CheckBuild          = "build"
+	CheckUnusedConstant = "unused-constant"
+	CheckShadowing      = "shadowing"
+	CheckOutputOnly     = "output-only"
+)
+
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	Check    string   `json:"check"`
+	File     string   `json:"file"`
+	// Line, Column, EndLine and EndColumn are 1-based, and 0 when the
+	// location is unknown
+	Line      int `json:"line,omitempty"`
+	Column    int `json:"column,omitempty"`
+	EndLine   int `json:"end_line,omitempty"`
+	EndColumn int `json:"end_column,omitempty"`
+	// Element is the full name of the descriptor the diagnostic applies to
+	Element string `json:"element"`
+	RuleId  string `json:"rule_id,omitempty"`
+	Expr    string `json:"expr,omitempty"`
+	Message string `json:"message"`
+}
+
+func (d *Diagnostic) String() string {
+	pos := d.File
+	if d.Line > 0 {
+		pos = fmt.Sprintf("%s:%d:%d", d.File, d.Line, d.Column)
+	}
+	return fmt.Sprintf("%s: %s: %s: %s", pos, d.Severity, d.Element, d.Message)
+}
+
+// field numbers of the options in the descriptor protos
+const (
+	fileOptionsNumber    = 8
+	messageOptionsNumber = 7
+	fieldOptionsNumber   = 8
+	serviceOptionsNumber = 3
+	methodOptionsNumber  = 4
+)
+
+type program struct {
+	desc    protoreflect.Descriptor
+	program *validate.Rule_Program
+	path    protoreflect.SourcePath
+}
+
+type declaration struct {
+	kind string
+	name string
+	desc protoreflect.Descriptor
+	path protoreflect.SourcePath
+}
+
+type linter struct {
+	file         protoreflect.FileDescriptor
+	config       *validate.Configuration
+	programs     []*program
+	declarations []*declaration
+	functions    []string
+	required     map[protoreflect.FullName]protoreflect.SourcePath
+	diagnostics  []*Diagnostic
+}
+
+// Lint builds every rule of the file, and checks for unused constants,
+// shadowed field names and rules on fields which cannot be set. Source
+// locations are only available when the descriptor has its SourceCodeInfo.
+func Lint(file protoreflect.FileDescriptor, config *validate.Configuration) []*Diagnostic {
+	l := &linter{
+		file:     file,
+		config:   config,
+		required: map[protoreflect.FullName]protoreflect.SourcePath{},
+	}
+	l.collect()
+	l.build()
+	l.checkConstants()
+	l.checkShadowing()
+	l.checkOutputOnlyFields()
+	sort.SliceStable(l.diagnostics, func(i, j int) bool {
+		a, b := l.diagnostics[i], l.diagnostics[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		} else if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return a.Message < b.Message
+	})
+	return l.diagnostics
+}
+
+// HasErrors reports whether one of the diagnostics is an error
+func HasErrors(diagnostics []*Diagnostic) bool {
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *linter) build() {
+	m, err := validate.NewUnregisteredManager(l.file,
+		validate.WithConfiguration(l.config),
+		validate.WithProgramErrorHandler(func(desc protoreflect.Descriptor, p *validate.Rule_Program, err error) {
+			var path protoreflect.SourcePath
+			for _, candidate := range l.programs {
+				if candidate.desc.FullName() == desc.FullName() && candidate.program.Id == p.Id && candidate.program.Expr == p.Expr {
+					path = candidate.path
+					break
+				}
+			}
+			d := l.report(SeverityError, CheckBuild, desc, path, err.Error())
+			d.RuleId, d.Expr = p.Id, p.Expr
+		}),
+	)
+	if err != nil {
+		l.report(SeverityError, CheckBuild, l.file, nil, err.Error())
+		return
+	}
+	for i := 0; i < l.file.Services().Len(); i++ {
+		sd := l.file.Services().Get(i)
+		if _, err := m.GetServiceRuleValidater(sd); err != nil {
+			l.report(SeverityError, CheckBuild, sd, nil, err.Error())
+		}
+	}
+	rangeMessages(l.file.Messages(), func(md protoreflect.MessageDescriptor) {
+		if _, err := m.GetMessageRuleValidater(md); err != nil {
+			l.report(SeverityError, CheckBuild, md, nil, err.Error())
+		}
+	})
+}
+
+func (l *linter) checkConstants() {
+	used := map[string]bool{}
+	exprs := append([]string{}, l.functions...)
+	for _, p := range l.programs {
+		exprs = append(exprs, p.program.Expr)
+	}
+	for _, expr := range exprs {
+		walkExpr(expr, func(e *exprpb.Expr) {
+			if ident := e.GetIdentExpr(); ident != nil {
+				used[ident.Name] = true
+			}
+		})
+	}
+	for _, d := range l.declarations {
+		if d.kind == "constant" && !used[d.name] {
+			l.report(SeverityWarning, CheckUnusedConstant, d.desc, d.path, fmt.Sprintf("constant %q is never used", d.name))
+		}
+	}
+}
+
+func (l *linter) checkShadowing() {
+	for _, d := range l.declarations {
+		var msgs []protoreflect.MessageDescriptor
+		switch desc := d.desc.(type) {
+		case protoreflect.FileDescriptor:
+			rangeMessages(desc.Messages(), func(md protoreflect.MessageDescriptor) { msgs = append(msgs, md) })
+		case protoreflect.MessageDescriptor:
+			msgs = append(msgs, desc)
+		case protoreflect.FieldDescriptor:
+			msgs = append(msgs, desc.ContainingMessage())
+		}
+		for _, md := range msgs {
+			if fd := md.Fields().ByName(protoreflect.Name(d.name)); fd != nil {
+				l.report(SeverityWarning, CheckShadowing, d.desc, d.path, fmt.Sprintf("%s %q shadows the field %s", d.kind, d.name, fd.FullName()))
+			}
+		}
+	}
+	for _, p := range l.programs {
+		names := map[string]string{}
+		switch desc := p.desc.(type) {
+		case protoreflect.ServiceDescriptor:
+			names["attribute_context"] = "the attribute_context variable"
+		case protoreflect.MethodDescriptor:
+			names["request"] = "the request variable"
+		case protoreflect.MessageDescriptor:
+			addFieldNames(names, desc)
+		case protoreflect.FieldDescriptor:
+			addFieldNames(names, desc.ContainingMessage())
+		}
+		walkExpr(p.program.Expr, func(e *exprpb.Expr) {
+			if c := e.GetComprehensionExpr(); c != nil {
+				if what, ok := names[c.IterVar]; ok {
+					d := l.report(SeverityWarning, CheckShadowing, p.desc, p.path, fmt.Sprintf("iteration variable %q shadows %s", c.IterVar, what))
+					d.RuleId, d.Expr = p.program.Id, p.program.Expr
+				}
+			}
+		})
+	}
+}
+
+func (l *linter) checkOutputOnlyFields() {
+	rangeMessages(l.file.Messages(), func(md protoreflect.MessageDescriptor) {
+		for i := 0; i < md.Fields().Len(); i++ {
+			fd := md.Fields().Get(i)
+			outputOnly, required := false, false
+			for _, behavior := range validate.GetExtension(fd.Options(), annotations.E_FieldBehavior).([]annotations.FieldBehavior) {
+				switch behavior {
+				case annotations.FieldBehavior_OUTPUT_ONLY:
+					outputOnly = true
+				case annotations.FieldBehavior_REQUIRED:
+					required = !l.config.GetRequiredSupportDisabled()
+				}
+			}
+			if !outputOnly {
+				continue
+			}
+			if path, ok := l.required[fd.FullName()]; ok || required {
+				l.report(SeverityWarning, CheckOutputOnly, fd, path, "required OUTPUT_ONLY field, which cannot be set in requests")
+				continue
+			}
+			for _, p := range l.programs {
+				if p.desc.FullName() == fd.FullName() {
+					l.report(SeverityWarning, CheckOutputOnly, fd, p.path, "rules on an OUTPUT_ONLY field, which cannot be set in requests")
+					break
+				}
+			}
+		}
+	})
+}
+
+func (l *linter) report(severity Severity, check string, desc protoreflect.Descriptor, path protoreflect.SourcePath, msg string) *Diagnostic {
+	d := &Diagnostic{
+		Severity: severity,
+		Check:    check,
+		File:     l.file.Path(),
+		Element:  string(desc.FullName()),
+		Message:  msg,
+	}
+	if path == nil {
+		path = sourcePath(desc)
+	}
+	// options are only located up to the option name, the deepest known
+	// location is used
+	locs := l.file.SourceLocations()
+	for n := len(path); n > 0; n-- {
+		if loc := locs.ByPath(path[:n]); loc.Path != nil {
+			d.Line, d.Column = loc.StartLine+1, loc.StartColumn+1
+			d.EndLine, d.EndColumn = loc.EndLine+1, loc.EndColumn+1
+			break
+		}
+	}
+	l.diagnostics = append(l.diagnostics, d)
+	return d
+}
+
+func (l *linter) collect() {
+	extNumber := int(validate.E_File.TypeDescriptor().Number())
+	cfg := l.config.GetRule()
+	fr := validate.GetExtension(l.file.Options(), validate.E_File).(*validate.FileRule)
+	frPath := protoreflect.SourcePath{fileOptionsNumber, int32(extNumber)}
+	l.addOptions(l.file, fr.GetOptions(), join(frPath, 1), true)
+	for i := 0; i < l.file.Services().Len(); i++ {
+		sd := l.file.Services().Get(i)
+		l.addServiceRule(sd, cfg.GetServiceRules()[string(sd.FullName())], nil, false)
+		l.addServiceRule(sd, fr.GetServiceRules()[string(sd.FullName())], join(frPath, 2), false)
+		l.addServiceRule(sd, validate.GetExtension(sd.Options(), validate.E_Service).(*validate.ServiceRule), join(sourcePath(sd), serviceOptionsNumber, extNumber), true)
+		for j := 0; j < sd.Methods().Len(); j++ {
+			md := sd.Methods().Get(j)
+			mr := validate.GetExtension(md.Options(), validate.E_Method).(*validate.MethodRule)
+			l.addRule(md, mr.GetRule(), join(sourcePath(md), methodOptionsNumber, extNumber, 1), true)
+		}
+	}
+	rangeMessages(l.file.Messages(), func(md protoreflect.MessageDescriptor) {
+		l.addMessageRule(md, cfg.GetMessageRules()[string(md.FullName())], nil, false)
+		l.addMessageRule(md, fr.GetMessageRules()[string(md.FullName())], join(frPath, 3), false)
+		l.addMessageRule(md, validate.GetExtension(md.Options(), validate.E_Message).(*validate.MessageRule), join(sourcePath(md), messageOptionsNumber, extNumber), true)
+		for i := 0; i < md.Fields().Len(); i++ {
+			fd := md.Fields().Get(i)
+			l.addFieldRule(fd, validate.GetExtension(fd.Options(), validate.E_Field).(*validate.FieldRule), join(sourcePath(fd), fieldOptionsNumber, extNumber), true)
+		}
+	})
+}
+
+// The paths are exact when the rule is declared as an option of the element,
+// map entries are only located by their map field.
+
+func (l *linter) addServiceRule(sd protoreflect.ServiceDescriptor, sr *validate.ServiceRule, path protoreflect.SourcePath, exact bool) {
+	if sr == nil {
+		return
+	}
+	l.addOptions(sd, sr.Options, sub(path, exact, 1), exact)
+	l.addRule(sd, sr.Rule, sub(path, exact, 2), exact)
+	for name, mr := range sr.MethodRules {
+		if md := sd.Methods().ByName(protoreflect.Name(name)); md != nil {
+			l.addRule(md, mr.GetRule(), sub(path, exact, 3), false)
+		}
+	}
+}
+
+func (l *linter) addMessageRule(md protoreflect.MessageDescriptor, mr *validate.MessageRule, path protoreflect.SourcePath, exact bool) {
+	if mr == nil {
+		return
+	}
+	l.addOptions(md, mr.Options, sub(path, exact, 1), exact)
+	l.addRule(md, mr.Rule, sub(path, exact, 2), exact)
+	for name, fr := range mr.FieldRules {
+		if fd := md.Fields().ByName(protoreflect.Name(name)); fd != nil {
+			l.addFieldRule(fd, fr, sub(path, exact, 3), false)
+		}
+	}
+}
+
+func (l *linter) addFieldRule(fd protoreflect.FieldDescriptor, fr *validate.FieldRule, path protoreflect.SourcePath, exact bool) {
+	if fr == nil {
+		return
+	}
+	l.addRule(fd, fr.Rule, sub(path, exact, 1), exact)
+	if _, ok := l.required[fd.FullName()]; fr.Required && !ok {
+		l.required[fd.FullName()] = sub(path, exact, 2)
+	}
+}
+
+func (l *linter) addRule(desc protoreflect.Descriptor, rule *validate.Rule, path protoreflect.SourcePath, exact bool) {
+	if rule == nil {
+		return
+	}
+	l.addOptions(desc, rule.Options, sub(path, exact, 1), exact)
+	for i, p := range rule.Programs {
+		if p.Disabled {
+			continue
+		}
+		l.programs = append(l.programs, &program{desc: desc, program: p, path: sub(path, exact, 2, i, 2)})
+	}
+}
+
+// addOptions records the declarations of the options, the ones of the
+// configuration file are ignored as they are shared by several files
+func (l *linter) addOptions(desc protoreflect.Descriptor, options *validate.Options, path protoreflect.SourcePath, exact bool) {
+	if options == nil {
+		return
+	}
+	for _, body := range options.GetGlobals().GetFunctions() {
+		l.functions = append(l.functions, body)
+	}
+	if path == nil {
+		return
+	}
+	for name := range options.GetGlobals().GetConstants() {
+		l.declarations = append(l.declarations, &declaration{kind: "constant", name: name, desc: desc, path: sub(path, exact, 1, 2)})
+	}
+	for name := range options.GetOverloads().GetVariables() {
+		l.declarations = append(l.declarations, &declaration{kind: "variable", name: name, desc: desc, path: sub(path, exact, 2, 2)})
+	}
+}
+
+func addFieldNames(names map[string]string, md protoreflect.MessageDescriptor) {
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		names[fd.TextName()] = fmt.Sprintf("the field %s", fd.FullName())
+	}
+}
+
+func rangeMessages(msgs protoreflect.MessageDescriptors, fn func(md protoreflect.MessageDescriptor)) {
+	for i := 0; i < msgs.Len(); i++ {
+		if md := msgs.Get(i); !md.IsMapEntry() {
+			fn(md)
+			rangeMessages(md.Messages(), fn)
+		}
+	}
+}
+
+// walkExpr calls fn on every node of the parsed expression, the parse errors
+// are reported when building the rules
+func walkExpr(expr string, fn func(e *exprpb.Expr)) {
+	p, err := parser.NewParser(parser.Macros(parser.AllMacros...))
+	if err != nil {
+		return
+	}
+	parsed, errs := p.Parse(common.NewTextSource(expr))
+	if len(errs.GetErrors()) > 0 {
+		return
+	}
+	var walk func(e *exprpb.Expr)
+	walk = func(e *exprpb.Expr) {
+		if e == nil {
+			return
+		}
+		fn(e)
+		switch k := e.ExprKind.(type) {
+		case *exprpb.Expr_SelectExpr:
+			walk(k.SelectExpr.Operand)
+		case *exprpb.Expr_CallExpr:
+			walk(k.CallExpr.Target)
+			for _, arg := range k.CallExpr.Args {
+				walk(arg)
+			}
+		case *exprpb.Expr_ListExpr:
+			for _, elem := range k.ListExpr.Elements {
+				walk(elem)
+			}
+		case *exprpb.Expr_StructExpr:
+			for _, entry := range k.StructExpr.Entries {
+				walk(entry.GetMapKey())
+				walk(entry.Value)
+			}
+		case *exprpb.Expr_ComprehensionExpr:
+			walk(k.ComprehensionExpr.IterRange)
+			walk(k.ComprehensionExpr.AccuInit)
+			walk(k.ComprehensionExpr.LoopCondition)
+			walk(k.ComprehensionExpr.LoopStep)
+			walk(k.ComprehensionExpr.Result)
+		}
+	}
+	walk(parsed.GetExpr())
+}
+
+// sourcePath returns the path of the declaration of the descriptor
+func sourcePath(desc protoreflect.Descriptor) protoreflect.SourcePath {
+	switch d := desc.(type) {
+	case protoreflect.FileDescriptor:
+		return protoreflect.SourcePath{}
+	case protoreflect.MessageDescriptor:
+		if parent, ok := d.Parent().(protoreflect.MessageDescriptor); ok {
+			return join(sourcePath(parent), 3, d.Index())
+		}
+		return protoreflect.SourcePath{4, int32(d.Index())}
+	case protoreflect.FieldDescriptor:
+		if !d.IsExtension() {
+			return join(sourcePath(d.Parent()), 2, d.Index())
+		} else if parent, ok := d.Parent().(protoreflect.MessageDescriptor); ok {
+			return join(sourcePath(parent), 6, d.Index())
+		}
+		return protoreflect.SourcePath{7, int32(d.Index())}
+	case protoreflect.ServiceDescriptor:
+		return protoreflect.SourcePath{6, int32(d.Index())}
+	case protoreflect.MethodDescriptor:
+		return join(sourcePath(d.Parent()), 2, d.Index())
+	}
+	return nil
+}
+
+func join(path protoreflect.SourcePath, elems ...int) protoreflect.SourcePath {
+	if path == nil {
+		return nil
+	}
+	p := make(protoreflect.SourcePath, 0, len(path)+len(elems))
+	p = append(p, path...)
+	for _, elem := range elems {
+		p = append(p, int32(elem))
+	}
+	return p
+}
+
+func sub(path protoreflect.SourcePath, exact bool, elems ...int) protoreflect.SourcePath {
+	if !exact {
+		return path
+	}
+	return join(path, elems...)
+}
@@ -0,0 +1,117 @@
+package lint
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func loadFile(t *testing.T) protoreflect.FileDescriptor {
+	b, err := os.ReadFile("../../testdata/cmd/lint/lint.pb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err = proto.Unmarshal(b, set); err != nil {
+		t.Fatal(err)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd, err := files.FindFileByPath("testdata/cmd/lint/lint.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fd
+}
+
+func TestLint(t *testing.T) {
+	type want struct {
+		Severity Severity
+		Line     int
+		Element  string
+		Message  string
+	}
+	tests := []struct {
+		Name   string
+		Config *validate.Configuration
+		Want   []want
+	}{
+		{
+			Name: "Proto",
+			Want: []want{
+				{SeverityError, 12, "testdata.lint.LintService.Lint", "compile error"},
+				{SeverityWarning, 24, "testdata.lint.LintRequest", `constant "unused_const" is never used`},
+				{SeverityWarning, 24, "testdata.lint.LintRequest", `iteration variable "name" shadows the field testdata.lint.LintRequest.name`},
+				{SeverityError, 43, "testdata.lint.LintRequest.name", "output type not bool"},
+				{SeverityWarning, 57, "testdata.lint.LintRequest.id", "rules on an OUTPUT_ONLY field"},
+				{SeverityWarning, 66, "testdata.lint.ShadowRequest", `constant "name" shadows the field testdata.lint.ShadowRequest.name`},
+				{SeverityError, 74, "testdata.lint.ShadowRequest.name", "build macros error"},
+			},
+		},
+		{
+			Name: "Configuration",
+			Config: &validate.Configuration{
+				Rule: &validate.FileRule{
+					ServiceRules: map[string]*validate.ServiceRule{
+						"testdata.lint.LintService": {
+							Rule: &validate.Rule{Programs: []*validate.Rule_Program{{Expr: "attribute_context"}}},
+						},
+					},
+					MessageRules: map[string]*validate.MessageRule{
+						"testdata.lint.LintRequest": {
+							FieldRules: map[string]*validate.FieldRule{
+								"id": {Required: true},
+							},
+						},
+					},
+				},
+			},
+			Want: []want{
+				{SeverityError, 10, "testdata.lint.LintService", "output type not bool"},
+				{SeverityError, 12, "testdata.lint.LintService.Lint", "compile error"},
+				{SeverityWarning, 24, "testdata.lint.LintRequest", `constant "unused_const" is never used`},
+				{SeverityWarning, 24, "testdata.lint.LintRequest", `iteration variable "name" shadows the field testdata.lint.LintRequest.name`},
+				{SeverityError, 43, "testdata.lint.LintRequest.name", "output type not bool"},
+				{SeverityWarning, 55, "testdata.lint.LintRequest.id", "required OUTPUT_ONLY field"},
+				{SeverityWarning, 66, "testdata.lint.ShadowRequest", `constant "name" shadows the field testdata.lint.ShadowRequest.name`},
+				{SeverityError, 74, "testdata.lint.ShadowRequest.name", "build macros error"},
+			},
+		},
+	}
+	file := loadFile(t)
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			diagnostics := Lint(file, tt.Config)
+			if len(diagnostics) != len(tt.Want) {
+				t.Fatalf("want %d diagnostics, got %d: %v", len(tt.Want), len(diagnostics), diagnostics)
+			}
+			for i, d := range diagnostics {
+				w := tt.Want[i]
+				if d.Severity != w.Severity || d.Line != w.Line || d.Element != w.Element || !strings.HasPrefix(d.Message, w.Message) {
+					t.Errorf("want %v, got %v", w, d)
+				}
+			}
+			if !HasErrors(diagnostics) {
+				t.Error("want errors")
+			}
+		})
+	}
+}
+
+func TestLintUnregistered(t *testing.T) {
+	file := loadFile(t)
+	Lint(file, nil)
+	// a registered manager, already used by the linter, would refuse the library
+	if err := validate.LoadLibrary(string(file.Package()), &validate.Library{}); err != nil {
+		t.Errorf("want the linted file not to be registered, got %v", err)
+	}
+}
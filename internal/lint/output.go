@@ -0,0 +1,171 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+type Format string
+
+const (
+	FormatJSON  Format = "json"
+	FormatSARIF Format = "sarif"
+)
+
+// WriteFile writes the diagnostics to the file in the given format
+func WriteFile(path string, format Format, diagnostics []*Diagnostic) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = Write(f, format, diagnostics); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func Write(w io.Writer, format Format, diagnostics []*Diagnostic) error {
+	var v interface{}
+	switch format {
+	case FormatJSON:
+		if diagnostics == nil {
+			diagnostics = []*Diagnostic{}
+		}
+		v = diagnostics
+	case FormatSARIF:
+		v = newSarifLog(diagnostics)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// SARIF 2.1.0 subset, see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+var checkDescriptions = map[string]string{
+	CheckBuild:          "The rule cannot be built.",
+	CheckUnusedConstant: "The constant is never used by the rules.",
+	CheckShadowing:      "The identifier shadows a field or a variable of the rule.",
+	CheckOutputOnly:     "The rule applies to an OUTPUT_ONLY field, which cannot be set in requests.",
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+func newSarifLog(diagnostics []*Diagnostic) *sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "protoc-gen-cel-validate",
+			InformationURI: "https://github.com/nlachfr/protoc-gen-cel-validate",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	for _, check := range []string{CheckBuild, CheckUnusedConstant, CheckShadowing, CheckOutputOnly} {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			Id:               check,
+			ShortDescription: sarifMessage{Text: checkDescriptions[check]},
+		})
+	}
+	for _, d := range diagnostics {
+		result := sarifResult{
+			RuleId:  d.Check,
+			Level:   string(d.Severity),
+			Message: sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: d.File}},
+				LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: d.Element}},
+			}},
+		}
+		if d.Line > 0 {
+			result.Locations[0].PhysicalLocation.Region = &sarifRegion{
+				StartLine:   d.Line,
+				StartColumn: d.Column,
+				EndLine:     d.EndLine,
+				EndColumn:   d.EndColumn,
+			}
+		}
+		if d.RuleId != "" || d.Expr != "" {
+			result.Properties = map[string]string{}
+			if d.RuleId != "" {
+				result.Properties["ruleId"] = d.RuleId
+			}
+			if d.Expr != "" {
+				result.Properties["expr"] = d.Expr
+			}
+		}
+		run.Results = append(run.Results, result)
+	}
+	return &sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}}
+}
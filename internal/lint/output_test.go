@@ -0,0 +1,112 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	diagnostics := []*Diagnostic{
+		{
+			Severity: SeverityError,
+			Check:    CheckBuild,
+			File:     "file.proto",
+			Line:     2,
+			Column:   3,
+			Element:  "pkg.Message.field",
+			RuleId:   "id",
+			Expr:     "field",
+			Message:  "output type not bool",
+		},
+		{
+			Severity: SeverityWarning,
+			Check:    CheckUnusedConstant,
+			File:     "file.proto",
+			Element:  "pkg.Message",
+			Message:  `constant "c" is never used`,
+		},
+	}
+	tests := []struct {
+		Name    string
+		Format  Format
+		Want    string
+		WantErr bool
+	}{
+		{
+			Name:    "Unknown format",
+			Format:  "xml",
+			WantErr: true,
+		},
+		{
+			Name:   "JSON",
+			Format: FormatJSON,
+			Want: `[
+				{"severity":"error","check":"build","file":"file.proto","line":2,"column":3,"element":"pkg.Message.field","rule_id":"id","expr":"field","message":"output type not bool"},
+				{"severity":"warning","check":"unused-constant","file":"file.proto","element":"pkg.Message","message":"constant \"c\" is never used"}
+			]`,
+		},
+		{
+			Name:   "SARIF",
+			Format: FormatSARIF,
+			Want: `{
+				"version":"2.1.0",
+				"$schema":"https://json.schemastore.org/sarif-2.1.0.json",
+				"runs":[{
+					"tool":{"driver":{
+						"name":"protoc-gen-cel-validate",
+						"informationUri":"https://github.com/nlachfr/protoc-gen-cel-validate",
+						"rules":[
+							{"id":"build","shortDescription":{"text":"The rule cannot be built."}},
+							{"id":"unused-constant","shortDescription":{"text":"The constant is never used by the rules."}},
+							{"id":"shadowing","shortDescription":{"text":"The identifier shadows a field or a variable of the rule."}},
+							{"id":"output-only","shortDescription":{"text":"The rule applies to an OUTPUT_ONLY field, which cannot be set in requests."}}
+						]
+					}},
+					"results":[
+						{
+							"ruleId":"build",
+							"level":"error",
+							"message":{"text":"output type not bool"},
+							"locations":[{
+								"physicalLocation":{"artifactLocation":{"uri":"file.proto"},"region":{"startLine":2,"startColumn":3}},
+								"logicalLocations":[{"fullyQualifiedName":"pkg.Message.field"}]
+							}],
+							"properties":{"expr":"field","ruleId":"id"}
+						},
+						{
+							"ruleId":"unused-constant",
+							"level":"warning",
+							"message":{"text":"constant \"c\" is never used"},
+							"locations":[{
+								"physicalLocation":{"artifactLocation":{"uri":"file.proto"}},
+								"logicalLocations":[{"fullyQualifiedName":"pkg.Message"}]
+							}]
+						}
+					]
+				}]
+			}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			if err := Write(buf, tt.Format, diagnostics); (err != nil) != tt.WantErr {
+				t.Fatalf("wantErr %v, got %v", tt.WantErr, err)
+			} else if err != nil {
+				return
+			}
+			var got, want interface{}
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			} else if err = json.Unmarshal([]byte(tt.Want), &want); err != nil {
+				t.Fatal(err)
+			}
+			gotRaw, _ := json.Marshal(got)
+			wantRaw, _ := json.Marshal(want)
+			if !bytes.Equal(gotRaw, wantRaw) {
+				t.Errorf("want %s, got %s", wantRaw, gotRaw)
+			}
+		})
+	}
+}
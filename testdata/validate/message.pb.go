@@ -302,6 +302,179 @@ func (x *MessageLocalOptions) GetName() string {
 	return ""
 }
 
+type MessageOverride struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *MessageOverride) Reset() {
+	*x = MessageOverride{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testdata_validate_message_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageOverride) ProtoMessage() {}
+
+func (x *MessageOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_testdata_validate_message_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageOverride.ProtoReflect.Descriptor instead.
+func (*MessageOverride) Descriptor() ([]byte, []int) {
+	return file_testdata_validate_message_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MessageOverride) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type MessageSensitive struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Password string                  `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Nested   *MessageSensitiveNested `protobuf:"bytes,3,opt,name=nested,proto3" json:"nested,omitempty"`
+	Tokens   []string                `protobuf:"bytes,4,rep,name=tokens,proto3" json:"tokens,omitempty"`
+}
+
+func (x *MessageSensitive) Reset() {
+	*x = MessageSensitive{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testdata_validate_message_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageSensitive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageSensitive) ProtoMessage() {}
+
+func (x *MessageSensitive) ProtoReflect() protoreflect.Message {
+	mi := &file_testdata_validate_message_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageSensitive.ProtoReflect.Descriptor instead.
+func (*MessageSensitive) Descriptor() ([]byte, []int) {
+	return file_testdata_validate_message_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MessageSensitive) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MessageSensitive) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *MessageSensitive) GetNested() *MessageSensitiveNested {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *MessageSensitive) GetTokens() []string {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type MessageSensitiveNested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Label  string `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Secret string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+}
+
+func (x *MessageSensitiveNested) Reset() {
+	*x = MessageSensitiveNested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testdata_validate_message_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageSensitiveNested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageSensitiveNested) ProtoMessage() {}
+
+func (x *MessageSensitiveNested) ProtoReflect() protoreflect.Message {
+	mi := &file_testdata_validate_message_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageSensitiveNested.ProtoReflect.Descriptor instead.
+func (*MessageSensitiveNested) Descriptor() ([]byte, []int) {
+	return file_testdata_validate_message_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MessageSensitiveNested) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *MessageSensitiveNested) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
 var File_testdata_validate_message_proto protoreflect.FileDescriptor
 
 var file_testdata_validate_message_proto_rawDesc = []byte{
@@ -337,12 +510,33 @@ var file_testdata_validate_message_proto_rawDesc = []byte{
 	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x3a,
 	0x2d, 0xd2, 0x49, 0x2a, 0x12, 0x28, 0x0a, 0x11, 0x0a, 0x0f, 0x12, 0x0d, 0x0a, 0x09, 0x65, 0x6d,
 	0x70, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x00, 0x12, 0x13, 0x12, 0x11, 0x6e, 0x61, 0x6d,
-	0x65, 0x20, 0x21, 0x3d, 0x20, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x3e,
-	0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x6c, 0x61,
-	0x63, 0x68, 0x66, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d,
-	0x63, 0x65, 0x6c, 0x2d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x74, 0x65, 0x73,
-	0x74, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x20, 0x21, 0x3d, 0x20, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x52,
+	0x0a, 0x0f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x3a, 0x2b, 0xd2, 0x49, 0x28, 0x12, 0x26, 0x12, 0x0c, 0x0a, 0x08,
+	0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x01, 0x12, 0x16, 0x0a, 0x08, 0x6f, 0x76,
+	0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x20, 0x21, 0x3d, 0x20,
+	0x22, 0x22, 0x22, 0xa7, 0x01, 0x0a, 0x10, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x65,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x08, 0x70,
+	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0x80,
+	0x01, 0x01, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x41, 0x0a, 0x06,
+	0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76,
+	0x65, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12,
+	0x1b, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x42,
+	0x03, 0x80, 0x01, 0x01, 0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x22, 0x4b, 0x0a, 0x16,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65,
+	0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x1b, 0x0a, 0x06,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0x80, 0x01,
+	0x01, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x6c, 0x61, 0x63, 0x68, 0x66, 0x72, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x63, 0x65, 0x6c, 0x2d, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61,
+	0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -357,23 +551,27 @@ func file_testdata_validate_message_proto_rawDescGZIP() []byte {
 	return file_testdata_validate_message_proto_rawDescData
 }
 
-var file_testdata_validate_message_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_testdata_validate_message_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_testdata_validate_message_proto_goTypes = []interface{}{
-	(*Message)(nil),             // 0: testdata.validate.Message
-	(*MessageExpr)(nil),         // 1: testdata.validate.MessageExpr
-	(*MessageNested)(nil),       // 2: testdata.validate.MessageNested
-	(*MessageNestedExpr)(nil),   // 3: testdata.validate.MessageNestedExpr
-	(*MessageOptions)(nil),      // 4: testdata.validate.MessageOptions
-	(*MessageLocalOptions)(nil), // 5: testdata.validate.MessageLocalOptions
+	(*Message)(nil),                // 0: testdata.validate.Message
+	(*MessageExpr)(nil),            // 1: testdata.validate.MessageExpr
+	(*MessageNested)(nil),          // 2: testdata.validate.MessageNested
+	(*MessageNestedExpr)(nil),      // 3: testdata.validate.MessageNestedExpr
+	(*MessageOptions)(nil),         // 4: testdata.validate.MessageOptions
+	(*MessageLocalOptions)(nil),    // 5: testdata.validate.MessageLocalOptions
+	(*MessageOverride)(nil),        // 6: testdata.validate.MessageOverride
+	(*MessageSensitive)(nil),       // 7: testdata.validate.MessageSensitive
+	(*MessageSensitiveNested)(nil), // 8: testdata.validate.MessageSensitiveNested
 }
 var file_testdata_validate_message_proto_depIdxs = []int32{
 	1, // 0: testdata.validate.MessageNested.message_expr:type_name -> testdata.validate.MessageExpr
 	1, // 1: testdata.validate.MessageNestedExpr.message_expr:type_name -> testdata.validate.MessageExpr
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	8, // 2: testdata.validate.MessageSensitive.nested:type_name -> testdata.validate.MessageSensitiveNested
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_testdata_validate_message_proto_init() }
@@ -454,6 +652,42 @@ func file_testdata_validate_message_proto_init() {
 				return nil
 			}
 		}
+		file_testdata_validate_message_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MessageOverride); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testdata_validate_message_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MessageSensitive); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testdata_validate_message_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MessageSensitiveNested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -461,7 +695,7 @@ func file_testdata_validate_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_testdata_validate_message_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
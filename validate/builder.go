@@ -2,50 +2,74 @@ package validate
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	"github.com/google/cel-go/cel"
 	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/genproto/googleapis/rpc/context/attribute_context"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
 type builder struct {
-	ob     overloadBuilder
-	opts   *Configuration
-	envOpt cel.EnvOption
+	ob               overloadBuilder
+	opts             *Configuration
+	envOpt           cel.EnvOption
+	resolvers        []*resolver
+	costLimit        uint64
+	programCostLimit uint64
+	onProgramError   func(desc protoreflect.Descriptor, program *Rule_Program, err error)
+	files            *protoregistry.Files
+	checked          bool
+	lenient          bool
+	warn             func(err error)
+	shadow           *atomic.Bool
+	observer         Observer
+	explain          bool
+	coverage         *Coverage
+	nested           func(desc protoreflect.MessageDescriptor) (MessageRuleValidater, error)
 }
 
 func newBuilder() *builder {
-	return &builder{ob: &defaultOverloadBuilder{}}
+	return &builder{ob: &defaultOverloadBuilder{}, shadow: &atomic.Bool{}}
 }
 
 func (b *builder) BuildServiceRuleValidater(desc protoreflect.ServiceDescriptor) (ServiceRuleValidater, error) {
 	serviceRule := &ServiceRule{
 		Options: &Options{},
 	}
+	src := sources{}
 	if b.opts != nil && b.opts.Rule != nil {
 		proto.Merge(serviceRule.Options, b.opts.Rule.Options)
 		if sr, ok := b.opts.Rule.ServiceRules[string(desc.FullName())]; ok {
-			proto.Merge(serviceRule, sr)
+			if err := src.mergeServiceRule(serviceRule, sr, SourceConfiguration); err != nil {
+				return nil, err
+			}
 		}
 	}
 	if fr := GetExtension(desc.ParentFile().Options(), E_File).(*FileRule); fr != nil {
 		proto.Merge(serviceRule.Options, fr.Options)
 		if sr, ok := fr.ServiceRules[string(desc.FullName())]; ok {
-			proto.Merge(serviceRule, sr)
+			if err := src.mergeServiceRule(serviceRule, sr, SourceFileOption); err != nil {
+				return nil, err
+			}
 		}
 	}
 	if sr := GetExtension(desc.Options(), E_Service).(*ServiceRule); sr != nil {
-		proto.Merge(serviceRule, sr)
+		if err := src.mergeServiceRule(serviceRule, sr, SourceServiceOption); err != nil {
+			return nil, err
+		}
 	}
 	rule := &Rule{
 		Options: &Options{},
 	}
 	proto.Merge(rule.Options, serviceRule.Options)
 	if serviceRule.Rule != nil {
-		proto.Merge(rule, serviceRule.Rule)
+		proto.Merge(rule.Options, serviceRule.Rule.Options)
+		rule.Programs = serviceRule.Rule.Programs
 	}
+	enabledPrograms(rule)
 	lib := &Library{}
 	if b.envOpt != nil {
 		lib.EnvOpts = append(lib.EnvOpts, b.envOpt)
@@ -54,6 +78,8 @@ func (b *builder) BuildServiceRuleValidater(desc protoreflect.ServiceDescriptor)
 		cel.TypeDescs(attribute_context.File_google_rpc_context_attribute_context_proto),
 		cel.Variable("attribute_context", cel.ObjectType(string((&attribute_context.AttributeContext{}).ProtoReflect().Descriptor().FullName()))),
 	)
+	lib.EnvOpts = append(lib.EnvOpts, b.buildResourceEnvOpts(desc)...)
+	lib.EnvOpts = append(lib.EnvOpts, buildContextEnvOption(b.resolvers))
 	methodDescs := map[string]protoreflect.MethodDescriptor{}
 	methodRulesValidaters := map[string]MethodRuleValidater{}
 	for i := 0; i < desc.Methods().Len(); i++ {
@@ -68,13 +94,18 @@ func (b *builder) BuildServiceRuleValidater(desc protoreflect.ServiceDescriptor)
 	var ruleValidater RuleValidater
 	if len(rule.Programs) > 0 {
 		lib.EnvOpts = append(lib.EnvOpts, BuildEnvOption(rule.Options))
-		if rv, err := BuildRuleValidater(rule, cel.Lib(lib)); err != nil {
+		b.applyCostLimit(rule.Options)
+		if rv, err := b.buildRuleValidater(desc, rule, src, cel.Lib(lib), b.newCostEstimator(nil)); err != nil {
 			return nil, err
 		} else {
 			ruleValidater = rv
 		}
 	}
-	return &serviceRuleValidater{ruleValidater: ruleValidater, methodRulesValidaters: methodRulesValidaters}, nil
+	disabled, err := b.disabledPrograms()
+	if err != nil {
+		return nil, err
+	}
+	return &serviceRuleValidater{desc: desc, ruleValidater: ruleValidater, methodDescs: methodDescs, methodRulesValidaters: methodRulesValidaters, costLimit: b.costLimit, evaluation: evaluation{sensitive: b.sensitiveField, disabled: disabled, shadow: b.shadow, observer: b.observer, explain: b.explain, coverage: b.coverage}}, nil
 }
 
 func (b *builder) buildMethodRuleValidater(serviceRule *ServiceRule, desc protoreflect.MethodDescriptor, envOpt cel.EnvOption) (MethodRuleValidater, error) {
@@ -84,12 +115,15 @@ func (b *builder) buildMethodRuleValidater(serviceRule *ServiceRule, desc protor
 	rule := &Rule{
 		Options: &Options{},
 	}
+	src := sources{}
 	if b.opts != nil && b.opts.Rule != nil {
 		proto.Merge(rule.Options, b.opts.Rule.Options)
 		if sr, ok := b.opts.Rule.ServiceRules[string(desc.Parent().FullName())]; ok {
 			proto.Merge(rule.Options, sr.Options)
 			if mr, ok := sr.MethodRules[string(desc.Name())]; ok {
-				proto.Merge(rule, mr.Rule)
+				if err := src.mergeRule(rule, mr.Rule, SourceConfiguration); err != nil {
+					return nil, err
+				}
 			}
 		}
 	}
@@ -98,19 +132,28 @@ func (b *builder) buildMethodRuleValidater(serviceRule *ServiceRule, desc protor
 		if sr, ok := fr.ServiceRules[string(desc.Parent().FullName())]; ok {
 			proto.Merge(rule.Options, sr.Options)
 			if mr, ok := sr.MethodRules[string(desc.Name())]; ok {
-				proto.Merge(rule, mr.Rule)
+				if err := src.mergeRule(rule, mr.Rule, SourceFileOption); err != nil {
+					return nil, err
+				}
 			}
 		}
 	}
 	if serviceRule != nil {
 		proto.Merge(rule.Options, serviceRule.Options)
-		if mr, ok := serviceRule.MethodRules[string(desc.Name())]; ok {
-			proto.Merge(rule, mr.Rule)
+	}
+	if sr := GetExtension(desc.Parent().Options(), E_Service).(*ServiceRule); sr != nil {
+		if mr, ok := sr.MethodRules[string(desc.Name())]; ok {
+			if err := src.mergeRule(rule, mr.Rule, SourceServiceOption); err != nil {
+				return nil, err
+			}
 		}
 	}
 	if mr := GetExtension(desc.Options(), E_Method).(*MethodRule); mr != nil {
-		proto.Merge(rule, mr.Rule)
+		if err := src.mergeRule(rule, mr.Rule, SourceMethodOption); err != nil {
+			return nil, err
+		}
 	}
+	enabledPrograms(rule)
 	lib := &Library{}
 	if envOpt != nil {
 		lib.EnvOpts = append(lib.EnvOpts, envOpt)
@@ -125,7 +168,8 @@ func (b *builder) buildMethodRuleValidater(serviceRule *ServiceRule, desc protor
 	lib.EnvOpts = append(lib.EnvOpts, BuildEnvOption(rule.Options))
 	lib.EnvOpts = append(lib.EnvOpts, b.ob.buildOverloads(desc.Input())...)
 	if len(rule.Programs) > 0 {
-		if rv, err := BuildRuleValidater(rule, cel.Lib(lib)); err != nil {
+		b.applyCostLimit(rule.Options)
+		if rv, err := b.buildRuleValidater(desc, rule, src, cel.Lib(lib), b.newCostEstimator(b.fieldSizes(desc.Input(), "request."))); err != nil {
 			return nil, err
 		} else {
 			return &methodRuleValidater{validater: rv}, nil
@@ -138,28 +182,37 @@ func (b *builder) BuildMessageRuleValidater(desc protoreflect.MessageDescriptor)
 	messageRule := &MessageRule{
 		Options: &Options{},
 	}
+	src := sources{}
 	if b.opts != nil && b.opts.Rule != nil {
 		proto.Merge(messageRule.Options, b.opts.Rule.Options)
 		if mr, ok := b.opts.Rule.MessageRules[string(desc.FullName())]; ok {
-			proto.Merge(messageRule, mr)
+			if err := src.mergeMessageRule(messageRule, mr, SourceConfiguration); err != nil {
+				return nil, err
+			}
 		}
 	}
 	if fr := GetExtension(desc.ParentFile().Options(), E_File).(*FileRule); fr != nil {
 		proto.Merge(messageRule.Options, fr.Options)
 		if mr, ok := fr.MessageRules[string(desc.FullName())]; ok {
-			proto.Merge(messageRule, mr)
+			if err := src.mergeMessageRule(messageRule, mr, SourceFileOption); err != nil {
+				return nil, err
+			}
 		}
 	}
 	if mr := GetExtension(desc.Options(), E_Message).(*MessageRule); mr != nil {
-		proto.Merge(messageRule, mr)
+		if err := src.mergeMessageRule(messageRule, mr, SourceMessageOption); err != nil {
+			return nil, err
+		}
 	}
 	rule := &Rule{
 		Options: &Options{},
 	}
 	proto.Merge(rule.Options, messageRule.Options)
 	if messageRule.Rule != nil {
-		proto.Merge(rule, messageRule.Rule)
+		proto.Merge(rule.Options, messageRule.Rule.Options)
+		rule.Programs = messageRule.Rule.Programs
 	}
+	enabledPrograms(rule)
 	lib := &Library{EnvOpts: []cel.EnvOption{cel.TypeDescs(desc.ParentFile())}}
 	if b.envOpt != nil {
 		lib.EnvOpts = append(lib.EnvOpts, b.envOpt)
@@ -169,10 +222,13 @@ func (b *builder) BuildMessageRuleValidater(desc protoreflect.MessageDescriptor)
 	}
 	lib.EnvOpts = append(lib.EnvOpts, cel.DeclareContextProto(desc))
 	lib.EnvOpts = append(lib.EnvOpts, b.ob.buildOverloads(desc)...)
+	lib.EnvOpts = append(lib.EnvOpts, b.buildResourceEnvOpts(desc)...)
+	lib.EnvOpts = append(lib.EnvOpts, buildContextEnvOption(b.resolvers))
+	estimator := b.newCostEstimator(b.fieldSizes(desc, ""))
 	fieldRulesValidaters := map[string]FieldRuleValidater{}
 	for i := 0; i < desc.Fields().Len(); i++ {
 		fieldDesc := desc.Fields().Get(i)
-		if fieldValidater, err := b.buildFieldRuleValidater(messageRule, fieldDesc, cel.Lib(lib)); err != nil {
+		if fieldValidater, err := b.buildFieldRuleValidater(messageRule, fieldDesc, cel.Lib(lib), estimator); err != nil {
 			return nil, err
 		} else {
 			fieldRulesValidaters[string(fieldDesc.Name())] = fieldValidater
@@ -181,28 +237,95 @@ func (b *builder) BuildMessageRuleValidater(desc protoreflect.MessageDescriptor)
 	var ruleValidater RuleValidater
 	if len(rule.Programs) > 0 {
 		lib.EnvOpts = append(lib.EnvOpts, BuildEnvOption(rule.Options, desc))
-		if rv, err := BuildRuleValidater(rule, cel.Lib(lib)); err != nil {
+		b.applyCostLimit(rule.Options)
+		if rv, err := b.buildRuleValidater(desc, rule, src, cel.Lib(lib), estimator); err != nil {
 			return nil, err
 		} else {
 			ruleValidater = rv
 		}
 	}
-	return &messageRuleValidater{ruleValidater: ruleValidater, fieldRulesValidaters: fieldRulesValidaters}, nil
+	disabled, err := b.disabledPrograms()
+	if err != nil {
+		return nil, err
+	}
+	validater := &messageRuleValidater{desc: desc, ruleValidater: ruleValidater, fieldRulesValidaters: fieldRulesValidaters, costLimit: b.costLimit, evaluation: evaluation{sensitive: b.sensitiveField, disabled: disabled, shadow: b.shadow, observer: b.observer, explain: b.explain, coverage: b.coverage}}
+	if ob, ok := b.ob.(*fallbackOverloadBuilder); ok {
+		validater.nestedValidater = ob.messageRuleValidater
+	}
+	return validater, nil
 }
 
-func (b *builder) buildFieldRuleValidater(messageRule *MessageRule, desc protoreflect.FieldDescriptor, envOpt cel.EnvOption) (FieldRuleValidater, error) {
+// buildRuleValidater builds the programs of the rule. When a program error
+// handler is set, the failing programs are reported and skipped instead.
+func (b *builder) buildRuleValidater(desc protoreflect.Descriptor, rule *Rule, src sources, envOpt cel.EnvOption, estimator *costEstimator) (RuleValidater, error) {
+	validater := &ruleValidater{}
+	if b.onProgramError == nil {
+		rv, err := buildRuleValidater(rule, envOpt, estimator)
+		if err != nil {
+			return nil, err
+		}
+		for i, p := range rv.Programs() {
+			p.Source = src[rule.Programs[i]]
+		}
+		b.redactPrograms(desc, rv)
+		return rv, nil
+	}
+	for _, program := range rule.Programs {
+		if rv, err := buildRuleValidater(&Rule{Options: rule.Options, Programs: []*Rule_Program{program}}, envOpt, estimator); err != nil {
+			b.onProgramError(desc, program, err)
+		} else {
+			for _, p := range rv.Programs() {
+				p.Source = src[program]
+			}
+			b.redactPrograms(desc, rv)
+			validater.programs = append(validater.programs, rv.Programs()...)
+		}
+	}
+	return validater, nil
+}
+
+// disabledPrograms returns the programs disabled by the configuration
+func (b *builder) disabledPrograms() (*programSwitch, error) {
+	if b.opts == nil {
+		return nil, nil
+	}
+	return newProgramSwitch(b.opts.DisabledPrograms)
+}
+
+// applyCostLimit sets the default program cost limit, which cannot exceed the
+// validation cost limit
+func (b *builder) applyCostLimit(options *Options) {
+	if options.CostLimit == 0 {
+		options.CostLimit = b.programCostLimit
+	}
+	if b.costLimit > 0 && (options.CostLimit == 0 || options.CostLimit > b.costLimit) {
+		options.CostLimit = b.costLimit
+	}
+}
+
+func (b *builder) buildResourceEnvOpts(desc protoreflect.Descriptor) []cel.EnvOption {
+	if b.opts == nil || !b.opts.ResourceReferenceSupportDisabled {
+		return []cel.EnvOption{BuildResourceEnvOption(desc)}
+	}
+	return nil
+}
+
+func (b *builder) buildFieldRuleValidater(messageRule *MessageRule, desc protoreflect.FieldDescriptor, envOpt cel.EnvOption, estimator *costEstimator) (FieldRuleValidater, error) {
 	if desc == nil {
 		return nil, fmt.Errorf("nil desc")
 	}
 	rule := &Rule{
 		Options: &Options{},
 	}
+	src := sources{}
 	if b.opts != nil && b.opts.Rule != nil {
 		proto.Merge(rule.Options, b.opts.Rule.Options)
 		if mr, ok := b.opts.Rule.MessageRules[string(desc.Parent().FullName())]; ok {
 			proto.Merge(rule.Options, mr.Options)
 			if fr, ok := mr.FieldRules[string(desc.Name())]; ok {
-				proto.Merge(rule, fr.Rule)
+				if err := src.mergeRule(rule, fr.Rule, SourceConfiguration); err != nil {
+					return nil, err
+				}
 			}
 		}
 	}
@@ -211,20 +334,30 @@ func (b *builder) buildFieldRuleValidater(messageRule *MessageRule, desc protore
 		if mr, ok := fr.MessageRules[string(desc.Parent().FullName())]; ok {
 			proto.Merge(rule.Options, mr.Options)
 			if fr, ok := mr.FieldRules[string(desc.Name())]; ok {
-				proto.Merge(rule, fr.Rule)
+				if err := src.mergeRule(rule, fr.Rule, SourceFileOption); err != nil {
+					return nil, err
+				}
 			}
 		}
 	}
 	if messageRule != nil {
 		proto.Merge(rule.Options, messageRule.Options)
-		if fr, ok := messageRule.FieldRules[string(desc.Name())]; ok {
-			proto.Merge(rule, fr.Rule)
+	}
+	if mr := GetExtension(desc.Parent().Options(), E_Message).(*MessageRule); mr != nil {
+		if fr, ok := mr.FieldRules[string(desc.Name())]; ok {
+			if err := src.mergeRule(rule, fr.Rule, SourceMessageOption); err != nil {
+				return nil, err
+			}
 		}
 	}
-	required := false
+	required, requiredSource := false, Source(0)
 	if fr := GetExtension(desc.Options(), E_Field).(*FieldRule); fr != nil {
-		proto.Merge(rule, fr.Rule)
-		required = fr.Required
+		if err := src.mergeRule(rule, fr.Rule, SourceFieldOption); err != nil {
+			return nil, err
+		}
+		if fr.Required {
+			required, requiredSource = true, SourceFieldOption
+		}
 	}
 	lib := &Library{}
 	if envOpt != nil {
@@ -253,10 +386,10 @@ func (b *builder) buildFieldRuleValidater(messageRule *MessageRule, desc protore
 					expr = fmt.Sprintf(`%s.matches("%s")`, desc.TextName(), regexp)
 				}
 				if expr != "" {
-					rule.Programs = append(rule.Programs, &Rule_Program{
+					src.add(rule, &Rule_Program{
 						Id:   ref,
 						Expr: expr,
-					})
+					}, SourceResourceReference)
 				}
 			} else {
 				return nil, fmt.Errorf(`cannot find type "%s"`, ref)
@@ -265,18 +398,20 @@ func (b *builder) buildFieldRuleValidater(messageRule *MessageRule, desc protore
 	}
 	if b.opts != nil && !b.opts.RequiredSupportDisabled {
 		for _, behavior := range proto.GetExtension(desc.Options(), annotations.E_FieldBehavior).([]annotations.FieldBehavior) {
-			if behavior == annotations.FieldBehavior_REQUIRED {
-				required = true
+			if behavior == annotations.FieldBehavior_REQUIRED && !required {
+				required, requiredSource = true, SourceFieldBehavior
 			}
 		}
 	}
+	enabledPrograms(rule)
 	var ruleValidater RuleValidater
 	if len(rule.Programs) > 0 {
-		if rv, err := BuildRuleValidater(rule, envOpt); err != nil {
+		b.applyCostLimit(rule.Options)
+		if rv, err := b.buildRuleValidater(desc, rule, src, envOpt, estimator); err != nil {
 			return nil, err
 		} else {
 			ruleValidater = rv
 		}
 	}
-	return &fieldRuleValidater{validater: ruleValidater, required: required}, nil
+	return &fieldRuleValidater{validater: ruleValidater, required: required, requiredSource: requiredSource}, nil
 }
@@ -1,10 +1,12 @@
 package validate
 
 import (
+	"context"
 	"testing"
 
 	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func TestBuildServiceRuleValidater(t *testing.T) {
@@ -267,6 +269,57 @@ func TestBuildMessageRuleValidater(t *testing.T) {
 			MessageDesc: validate.File_testdata_validate_file_proto.Messages().ByName("FileRpc"),
 			WantErr:     false,
 		},
+		{
+			Name:        "Message config expr overridden and disabled",
+			MessageDesc: validate.File_testdata_validate_message_proto.Messages().ByName("MessageOverride"),
+			Configuration: &Configuration{
+				Rule: &FileRule{
+					MessageRules: map[string]*MessageRule{
+						"testdata.validate.MessageOverride": {
+							Rule: &Rule{Programs: []*Rule_Program{
+								{Id: "platform", Expr: `undefined`},
+								{Id: "override", Expr: `undefined`},
+							}},
+						},
+					},
+				},
+			},
+			WantErr: false,
+		},
+		{
+			Name:        "Message config expr not overridden",
+			MessageDesc: validate.File_testdata_validate_message_proto.Messages().ByName("MessageOverride"),
+			Configuration: &Configuration{
+				Rule: &FileRule{
+					MessageRules: map[string]*MessageRule{
+						"testdata.validate.MessageOverride": {
+							Rule: &Rule{Programs: []*Rule_Program{
+								{Id: "platform", Expr: `undefined`},
+								{Id: "other", Expr: `undefined`},
+							}},
+						},
+					},
+				},
+			},
+			WantErr: true,
+		},
+		{
+			Name:        "Message config expr with duplicate id",
+			MessageDesc: validate.File_testdata_validate_message_proto.Messages().ByName("Message"),
+			Configuration: &Configuration{
+				Rule: &FileRule{
+					MessageRules: map[string]*MessageRule{
+						"testdata.validate.Message": {
+							Rule: &Rule{Programs: []*Rule_Program{
+								{Id: "name", Expr: `name != ""`},
+								{Id: "name", Expr: `name != "name"`},
+							}},
+						},
+					},
+				},
+			},
+			WantErr: true,
+		},
 		{
 			Name:        "Field level expr",
 			MessageDesc: validate.File_testdata_validate_field_proto.Messages().ByName("FieldExpr"),
@@ -297,6 +350,73 @@ func TestBuildMessageRuleValidater(t *testing.T) {
 			MessageDesc: validate.File_testdata_validate_field_proto.Messages().ByName("FieldRepeatedReferenceType"),
 			WantErr:     false,
 		},
+		{
+			Name:        "Message resource functions",
+			MessageDesc: validate.File_testdata_validate_field_proto.Messages().ByName("Field"),
+			Configuration: &Configuration{
+				Rule: &FileRule{
+					MessageRules: map[string]*MessageRule{
+						string(validate.File_testdata_validate_field_proto.Messages().ByName("Field").FullName()): {
+							Rule: &Rule{Programs: []*Rule_Program{{Expr: `resourceType(name) == "testdata.validate/Field" && resourceVar(name, "field") != "" && resourceParent(name) == ""`}}},
+						},
+					},
+				},
+			},
+			WantErr: false,
+		},
+		{
+			Name:        "Message resource functions with resource reference support disabled",
+			MessageDesc: validate.File_testdata_validate_field_proto.Messages().ByName("Field"),
+			Configuration: &Configuration{
+				Rule: &FileRule{
+					MessageRules: map[string]*MessageRule{
+						string(validate.File_testdata_validate_field_proto.Messages().ByName("Field").FullName()): {
+							Rule: &Rule{Programs: []*Rule_Program{{Expr: `resourceType(name) == "testdata.validate/Field"`}}},
+						},
+					},
+				},
+				ResourceReferenceSupportDisabled: true,
+			},
+			WantErr: true,
+		},
+		{
+			Name:        "Estimated cost exceeded",
+			MessageDesc: validate.File_testdata_validate_field_proto.Messages().ByName("FieldReferenceType"),
+			Configuration: &Configuration{
+				MaxEstimatedCost: 100,
+			},
+			WantErr: true,
+		},
+		{
+			Name:        "Estimated cost with max size",
+			MessageDesc: validate.File_testdata_validate_field_proto.Messages().ByName("FieldReferenceType"),
+			Configuration: &Configuration{
+				Rule: &FileRule{
+					MessageRules: map[string]*MessageRule{
+						string(validate.File_testdata_validate_field_proto.Messages().ByName("FieldReferenceType").FullName()): {
+							FieldRules: map[string]*FieldRule{"name": {MaxSize: 10}},
+						},
+					},
+				},
+				MaxEstimatedCost: 100,
+			},
+			WantErr: false,
+		},
+		{
+			Name:        "Estimated cost exceeded with max size",
+			MessageDesc: validate.File_testdata_validate_field_proto.Messages().ByName("FieldReferenceType"),
+			Configuration: &Configuration{
+				Rule: &FileRule{
+					MessageRules: map[string]*MessageRule{
+						string(validate.File_testdata_validate_field_proto.Messages().ByName("FieldReferenceType").FullName()): {
+							FieldRules: map[string]*FieldRule{"name": {MaxSize: 1000000}},
+						},
+					},
+				},
+				MaxEstimatedCost: 100,
+			},
+			WantErr: true,
+		},
 		{
 			Name:        "Field level expr with missing const",
 			MessageDesc: validate.File_testdata_validate_field_proto.Messages().ByName("FieldOptions"),
@@ -377,3 +497,46 @@ func TestBuildMessageRuleValidater(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildFieldRuleValidaterDisabledGenerated(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Configuration *Configuration
+		WantErr       bool
+	}{
+		{
+			Name:    "Generated",
+			WantErr: true,
+		},
+		{
+			Name: "Generated disabled by id",
+			Configuration: &Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+				"testdata.TestRpcRequest": {FieldRules: map[string]*FieldRule{
+					"ref": {Rule: &Rule{Programs: []*Rule_Program{{Id: "testdata/Ref", Disabled: true}}}},
+				}},
+			}}},
+		},
+		{
+			Name: "Generated replaced by id",
+			Configuration: &Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+				"testdata.TestRpcRequest": {FieldRules: map[string]*FieldRule{
+					"ref": {Rule: &Rule{Programs: []*Rule_Program{{Id: "testdata/Ref", Expr: `ref.startsWith("b")`}}}},
+				}},
+			}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			b := newBuilder()
+			b.opts = tt.Configuration
+			v, err := b.BuildMessageRuleValidater((&validate.TestRpcRequest{}).ProtoReflect().Descriptor())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = v.ValidateWithMask(context.Background(), &validate.TestRpcRequest{Ref: "bad", Raw: "raw"}, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+			if (tt.WantErr && err == nil) || (!tt.WantErr && err != nil) {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			}
+		})
+	}
+}
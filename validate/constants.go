@@ -0,0 +1,111 @@
+package validate
+
+import (
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// Constants returns the literals of the program, and the values of the global
+// constants it references, macros expanded
+func (p *ValidateProgram) Constants() []ref.Val {
+	if p.explainer == nil || p.explainer.ast == nil {
+		return nil
+	}
+	checked, err := cel.AstToCheckedExpr(p.explainer.ast)
+	if err != nil {
+		return nil
+	}
+	return appendConstants(nil, checked.Expr, checked.ReferenceMap)
+}
+
+// Constants returns the literals of the programs of the message and of its
+// fields, without duplicates, e.g. to guide the generation of test messages
+func (v *messageRuleValidater) Constants() []ref.Val {
+	validaters := []RuleValidater{v.ruleValidater}
+	if v.desc != nil {
+		for i := 0; i < v.desc.Fields().Len(); i++ {
+			if fv, ok := v.fieldRulesValidaters[string(v.desc.Fields().Get(i).Name())]; ok && fv != nil {
+				validaters = append(validaters, fv.Validater())
+			}
+		}
+	}
+	constants := []ref.Val{}
+	for _, rv := range validaters {
+		if rv == nil {
+			continue
+		}
+		for _, p := range rv.Programs() {
+			for _, c := range p.Constants() {
+				if !containsConstant(constants, c) {
+					constants = append(constants, c)
+				}
+			}
+		}
+	}
+	return constants
+}
+
+func containsConstant(constants []ref.Val, c ref.Val) bool {
+	for _, other := range constants {
+		if other.Type() == c.Type() && other.Equal(c) == types.True {
+			return true
+		}
+	}
+	return false
+}
+
+func appendConstants(constants []ref.Val, e *exprpb.Expr, refs map[int64]*exprpb.Reference) []ref.Val {
+	if e == nil {
+		return constants
+	}
+	switch k := e.ExprKind.(type) {
+	case *exprpb.Expr_ConstExpr:
+		constants = appendConstant(constants, k.ConstExpr)
+	case *exprpb.Expr_IdentExpr:
+		if c := refs[e.Id].GetValue(); c != nil {
+			constants = appendConstant(constants, c)
+		}
+	case *exprpb.Expr_SelectExpr:
+		constants = appendConstants(constants, k.SelectExpr.Operand, refs)
+	case *exprpb.Expr_CallExpr:
+		constants = appendConstants(constants, k.CallExpr.Target, refs)
+		for _, arg := range k.CallExpr.Args {
+			constants = appendConstants(constants, arg, refs)
+		}
+	case *exprpb.Expr_ListExpr:
+		for _, elem := range k.ListExpr.Elements {
+			constants = appendConstants(constants, elem, refs)
+		}
+	case *exprpb.Expr_StructExpr:
+		for _, entry := range k.StructExpr.Entries {
+			constants = appendConstants(constants, entry.GetMapKey(), refs)
+			constants = appendConstants(constants, entry.Value, refs)
+		}
+	case *exprpb.Expr_ComprehensionExpr:
+		c := k.ComprehensionExpr
+		for _, sub := range []*exprpb.Expr{c.IterRange, c.AccuInit, c.LoopCondition, c.LoopStep, c.Result} {
+			constants = appendConstants(constants, sub, refs)
+		}
+	}
+	return constants
+}
+
+func appendConstant(constants []ref.Val, c *exprpb.Constant) []ref.Val {
+	switch c := c.ConstantKind.(type) {
+	case *exprpb.Constant_BoolValue:
+		constants = append(constants, types.Bool(c.BoolValue))
+	case *exprpb.Constant_Int64Value:
+		constants = append(constants, types.Int(c.Int64Value))
+	case *exprpb.Constant_Uint64Value:
+		constants = append(constants, types.Uint(c.Uint64Value))
+	case *exprpb.Constant_DoubleValue:
+		constants = append(constants, types.Double(c.DoubleValue))
+	case *exprpb.Constant_StringValue:
+		constants = append(constants, types.String(c.StringValue))
+	case *exprpb.Constant_BytesValue:
+		constants = append(constants, types.Bytes(c.BytesValue))
+	}
+	return constants
+}
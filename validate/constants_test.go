@@ -0,0 +1,35 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+)
+
+func TestMessageRuleValidaterConstants(t *testing.T) {
+	config := &Configuration{Rule: &FileRule{
+		Options: &Options{Globals: &Options_Globals{Constants: map[string]string{"name_const": "name"}}},
+		MessageRules: map[string]*MessageRule{
+			"testdata.validate.ManagerRpcRequest": {
+				FieldRules: map[string]*FieldRule{
+					"name": {Rule: &Rule{Programs: []*Rule_Program{
+						{Expr: `size(name) < 64`},
+						{Expr: `name in ["name", "other"] || name.startsWith(string(b"x"))`},
+					}}},
+				},
+			},
+		},
+	}}
+	m := newManager(validate.File_testdata_validate_manager_proto, WithConfiguration(config))
+	v, err := m.GetMessageRuleValidater(validate.File_testdata_validate_manager_proto.Messages().ByName("ManagerRpcRequest"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []ref.Val{types.String("name"), types.Int(64), types.String("other"), types.Bytes("x")}
+	if got := v.Constants(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,110 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter"
+)
+
+// contextVariable holds the validation context in the activation, so that
+// functions relying on it can be called with the caller's context.Context
+const contextVariable = "__validate_context__"
+
+var (
+	contextType      = cel.OpaqueType("cel.validate.Context")
+	contextTypeValue = types.NewTypeValue("cel.validate.Context")
+)
+
+type validationStateKey struct{}
+
+// validationState is shared by every program evaluated during a single validation,
+// including nested ones
+type validationState struct {
+	mu        sync.Mutex
+	cache     map[string]ref.Val
+	cost      uint64
+	costLimit uint64
+}
+
+func withValidationState(ctx context.Context, costLimit uint64) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := ctx.Value(validationStateKey{}).(*validationState); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, validationStateKey{}, &validationState{cache: map[string]ref.Val{}, costLimit: costLimit})
+}
+
+// addCost adds the cost of a program evaluation, and returns an error if the
+// cost limit of the validation is exceeded
+func (s *validationState) addCost(cost uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cost += cost
+	if s.costLimit > 0 && s.cost > s.costLimit {
+		return interpreter.EvalCancelledError{
+			Cause:   interpreter.CostLimitExceeded,
+			Message: "operation cancelled: validation cost limit exceeded",
+		}
+	}
+	return nil
+}
+
+// remaining returns the cost budget left to the validation, if limited
+func (s *validationState) remaining() (uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.costLimit == 0 {
+		return 0, false
+	} else if s.cost >= s.costLimit {
+		return 0, true
+	}
+	return s.costLimit - s.cost, true
+}
+
+func validationStateFromContext(ctx context.Context) *validationState {
+	if state, ok := ctx.Value(validationStateKey{}).(*validationState); ok {
+		return state
+	}
+	return nil
+}
+
+type contextValue struct {
+	ctx context.Context
+}
+
+func newContextValue(ctx context.Context) *contextValue {
+	return &contextValue{ctx: ctx}
+}
+
+func contextFromVal(val ref.Val) context.Context {
+	if v, ok := val.(*contextValue); ok && v.ctx != nil {
+		return v.ctx
+	}
+	return context.Background()
+}
+
+func (v *contextValue) ConvertToNative(typeDesc reflect.Type) (interface{}, error) {
+	if reflect.TypeOf(v.ctx).AssignableTo(typeDesc) {
+		return v.ctx, nil
+	}
+	return nil, fmt.Errorf("type conversion error from '%s' to '%v'", contextTypeValue.TypeName(), typeDesc)
+}
+
+func (v *contextValue) ConvertToType(typeVal ref.Type) ref.Val {
+	if typeVal == types.TypeType {
+		return contextTypeValue
+	}
+	return types.NewErr("type conversion error from '%s' to '%s'", contextTypeValue.TypeName(), typeVal.TypeName())
+}
+
+func (v *contextValue) Equal(other ref.Val) ref.Val { return types.Bool(v == other) }
+func (v *contextValue) Type() ref.Type              { return contextTypeValue }
+func (v *contextValue) Value() interface{}          { return v.ctx }
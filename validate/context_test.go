@@ -0,0 +1,49 @@
+package validate
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/google/cel-go/common/types"
+)
+
+func TestWithValidationState(t *testing.T) {
+	ctx := withValidationState(context.Background(), 0)
+	state := validationStateFromContext(ctx)
+	if state == nil {
+		t.Fatal("missing validation state")
+	}
+	if got := validationStateFromContext(withValidationState(ctx, 0)); got != state {
+		t.Errorf("want state %p, got %p", state, got)
+	}
+	if validationStateFromContext(context.Background()) != nil {
+		t.Errorf("want nil state")
+	}
+}
+
+func TestContextValue(t *testing.T) {
+	ctx := context.Background()
+	val := newContextValue(ctx)
+	if contextFromVal(val) != ctx {
+		t.Errorf("context mismatch")
+	}
+	if contextFromVal(types.String("")) == nil {
+		t.Errorf("want default context")
+	}
+	if native, err := val.ConvertToNative(reflect.TypeOf((*context.Context)(nil)).Elem()); err != nil || native != ctx {
+		t.Errorf("want context, got %v (%v)", native, err)
+	}
+	if _, err := val.ConvertToNative(reflect.TypeOf("")); err == nil {
+		t.Errorf("want conversion error")
+	}
+	if val.ConvertToType(types.TypeType) != contextTypeValue {
+		t.Errorf("want context type")
+	}
+	if !types.IsError(val.ConvertToType(types.StringType)) {
+		t.Errorf("want conversion error")
+	}
+	if val.Equal(val) != types.True || val.Equal(newContextValue(ctx)) != types.False {
+		t.Errorf("equality mismatch")
+	}
+}
@@ -0,0 +1,187 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var coverages = &listenerRegistry[*Coverage]{}
+
+// Coverage counts the outcomes of the program evaluations, to report the
+// programs a test suite never fails or never evaluates
+type Coverage struct {
+	mu       sync.Mutex
+	programs map[coverageKey]*ProgramCoverage
+}
+
+type coverageKey struct {
+	element protoreflect.FullName
+	id      string
+	expr    string
+}
+
+// ProgramCoverage counts the outcomes of a program, for the service, method,
+// message or field it applies to
+type ProgramCoverage struct {
+	Element protoreflect.FullName `json:"element"`
+	Id      string                `json:"id,omitempty"`
+	Expr    string                `json:"expr"`
+	Passed  int                   `json:"passed"`
+	Failed  int                   `json:"failed"`
+	Skipped int                   `json:"skipped"`
+}
+
+func (p *ProgramCoverage) String() string {
+	if p.Id != "" {
+		return fmt.Sprintf("%s [%s] %s", p.Element, p.Id, p.Expr)
+	}
+	return fmt.Sprintf("%s %s", p.Element, p.Expr)
+}
+
+// NewCoverage returns an empty coverage
+func NewCoverage() *Coverage {
+	return &Coverage{programs: map[coverageKey]*ProgramCoverage{}}
+}
+
+// WithCoverage records the program evaluations of the manager
+func WithCoverage(c *Coverage) ManagerOption {
+	return managerOption(func(b *builder) {
+		b.coverage = c
+	})
+}
+
+// EnableCoverage records the program evaluations of every manager, e.g. from
+// TestMain, until the returned function is called
+func EnableCoverage(c *Coverage) (disable func()) {
+	return coverages.add(c)
+}
+
+func (c *Coverage) record(desc protoreflect.Descriptor, pgr *ValidateProgram, outcome Outcome) {
+	if c == nil {
+		return
+	}
+	var element protoreflect.FullName
+	if desc != nil {
+		element = desc.FullName()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := coverageKey{element: element, id: pgr.Id, expr: pgr.Expr}
+	p, ok := c.programs[key]
+	if !ok {
+		p = &ProgramCoverage{Element: element, Id: pgr.Id, Expr: pgr.Expr}
+		c.programs[key] = p
+	}
+	switch outcome {
+	case OutcomePassed:
+		p.Passed++
+	case OutcomeFailed:
+		p.Failed++
+	case OutcomeSkipped:
+		p.Skipped++
+	}
+}
+
+// count returns a copy of the counts of the program
+func (c *Coverage) count(element protoreflect.FullName, id, expr string) ProgramCoverage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if p, ok := c.programs[coverageKey{element: element, id: id, expr: expr}]; ok {
+		return *p
+	}
+	return ProgramCoverage{Element: element, Id: id, Expr: expr}
+}
+
+// CoverageReport lists the programs of the files, with their coverage
+type CoverageReport struct {
+	Files []*FileCoverage `json:"files"`
+}
+
+// FileCoverage is the coverage of the programs of a file. A program is
+// evaluated when it passed or failed at least once, skips excepted.
+type FileCoverage struct {
+	Path           string             `json:"path"`
+	Programs       []*ProgramCoverage `json:"programs"`
+	NeverFailed    []*ProgramCoverage `json:"neverFailed"`
+	NeverEvaluated []*ProgramCoverage `json:"neverEvaluated"`
+}
+
+// Report returns the coverage of the programs of the managers, or of the
+// registered managers if none is given
+func (c *Coverage) Report(managers ...*Manager) (*CoverageReport, error) {
+	if len(managers) == 0 {
+		managers = Managers()
+	}
+	r := &CoverageReport{Files: []*FileCoverage{}}
+	for _, m := range managers {
+		d, err := m.Describe()
+		if err != nil {
+			return nil, fmt.Errorf("coverage of %s: %w", m.File().Path(), err)
+		}
+		f := &FileCoverage{Path: d.Name, Programs: []*ProgramCoverage{}, NeverFailed: []*ProgramCoverage{}, NeverEvaluated: []*ProgramCoverage{}}
+		add := func(element protoreflect.FullName, programs []*ProgramDescription) {
+			for _, pd := range programs {
+				p := c.count(element, pd.Id, pd.Expr)
+				f.Programs = append(f.Programs, &p)
+				if p.Passed+p.Failed == 0 {
+					f.NeverEvaluated = append(f.NeverEvaluated, &p)
+				} else if p.Failed == 0 {
+					f.NeverFailed = append(f.NeverFailed, &p)
+				}
+			}
+		}
+		for _, s := range d.Services {
+			add(s.Name, s.Programs)
+			for _, md := range s.Methods {
+				add(md.Name, md.Programs)
+			}
+		}
+		for _, md := range d.Messages {
+			add(md.Name, md.Programs)
+			for _, fd := range md.Fields {
+				add(fd.Name, fd.Programs)
+			}
+		}
+		r.Files = append(r.Files, f)
+	}
+	return r, nil
+}
+
+// WriteText writes the never failed and never evaluated programs of each file
+func (r *CoverageReport) WriteText(w io.Writer) error {
+	for _, f := range r.Files {
+		evaluated := len(f.Programs) - len(f.NeverEvaluated)
+		failed := evaluated - len(f.NeverFailed)
+		if _, err := fmt.Fprintf(w, "%s: %d/%d programs evaluated, %d failed\n", f.Path, evaluated, len(f.Programs), failed); err != nil {
+			return err
+		}
+		for _, section := range []struct {
+			title    string
+			programs []*ProgramCoverage
+		}{{"never evaluated", f.NeverEvaluated}, {"never failed", f.NeverFailed}} {
+			if len(section.programs) == 0 {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "  %s:\n", section.title); err != nil {
+				return err
+			}
+			for _, p := range section.programs {
+				if _, err := fmt.Fprintf(w, "    %s\n", p); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the report as JSON
+func (r *CoverageReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,93 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestCoverageReport(t *testing.T) {
+	config := &Configuration{Rule: &FileRule{
+		Options: &Options{Globals: &Options_Globals{Constants: map[string]string{"name_const": "name"}}},
+		MessageRules: map[string]*MessageRule{
+			"testdata.validate.ManagerRpcRequest": {
+				FieldRules: map[string]*FieldRule{
+					"name": {Rule: &Rule{Programs: []*Rule_Program{{Id: "size", Expr: `size(name) < 64`}}}},
+				},
+			},
+		},
+	}}
+	c := NewCoverage()
+	m := newManager(validate.File_testdata_validate_manager_proto, WithConfiguration(config), WithCoverage(c))
+	v, err := m.GetMessageRuleValidater(validate.File_testdata_validate_manager_proto.Messages().ByName("ManagerRpcRequest"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"name", "other"} {
+		v.ValidateWithMask(context.Background(), &validate.ManagerRpcRequest{Name: name}, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+	}
+	got, err := c.Report(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	method := &ProgramCoverage{Element: "testdata.validate.Manager.ManagerRpc", Expr: "request.name == name_const"}
+	message := &ProgramCoverage{Element: "testdata.validate.ManagerRpcRequest", Expr: "name == name_const", Passed: 1, Failed: 1}
+	field := &ProgramCoverage{Element: "testdata.validate.ManagerRpcRequest.name", Id: "size", Expr: "size(name) < 64", Passed: 1}
+	want := &CoverageReport{Files: []*FileCoverage{{
+		Path:           "testdata/validate/manager.proto",
+		Programs:       []*ProgramCoverage{method, message, field},
+		NeverFailed:    []*ProgramCoverage{field},
+		NeverEvaluated: []*ProgramCoverage{method},
+	}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want.Files[0], got.Files[0])
+	}
+	text := &bytes.Buffer{}
+	if err := got.WriteText(text); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantText := `testdata/validate/manager.proto: 2/3 programs evaluated, 1 failed
+  never evaluated:
+    testdata.validate.Manager.ManagerRpc request.name == name_const
+  never failed:
+    testdata.validate.ManagerRpcRequest.name [size] size(name) < 64
+`
+	if text.String() != wantText {
+		t.Errorf("want:\n%s\ngot:\n%s", wantText, text.String())
+	}
+	raw := &bytes.Buffer{}
+	if err := got.WriteJSON(raw); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decoded := &CoverageReport{}
+	if err := json.Unmarshal(raw.Bytes(), decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Errorf("want %+v, got %+v", want.Files[0], decoded.Files[0])
+	}
+}
+
+func TestEnableCoverage(t *testing.T) {
+	c := NewCoverage()
+	desc := validate.File_testdata_validate_message_proto.Messages().ByName("MessageExpr")
+	v, err := newManager(desc.ParentFile(), WithConfiguration(&Configuration{})).GetMessageRuleValidater(desc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	validate := func() {
+		v.ValidateWithMask(context.Background(), &validate.MessageExpr{}, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+	}
+	disable := EnableCoverage(c)
+	validate()
+	disable()
+	validate()
+	if got := c.count("testdata.validate.MessageExpr", "", `name != ""`); got.Failed != 1 {
+		t.Errorf("want 1 failure, got %+v", got)
+	}
+}
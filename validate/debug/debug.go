@@ -0,0 +1,153 @@
+// Package debug provides an http.Handler rendering the rules enforced by the
+// registered managers, and the recent violations.
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate/errors"
+)
+
+const defaultCapacity = 100
+
+type Option interface {
+	apply(h *Handler)
+}
+
+type handlerOption func(h *Handler)
+
+func (opt handlerOption) apply(h *Handler) { opt(h) }
+
+// WithCapacity sets the number of recent violations kept, 100 by default
+func WithCapacity(capacity int) Option {
+	return handlerOption(func(h *Handler) {
+		h.capacity = capacity
+	})
+}
+
+// Violation is a recorded validation failure
+type Violation struct {
+	Time        time.Time `json:"time"`
+	Descriptor  string    `json:"descriptor,omitempty"`
+	Operation   string    `json:"operation,omitempty"`
+	ProgramId   string    `json:"programId,omitempty"`
+	Expr        string    `json:"expr,omitempty"`
+	Kind        string    `json:"kind"`
+	Error       string    `json:"error"`
+	Explanation string    `json:"explanation,omitempty"`
+}
+
+// File describes the rules of a registered manager
+type File struct {
+	Path        string                    `json:"path"`
+	Description *validate.FileDescription `json:"description,omitempty"`
+}
+
+// Page is the content rendered by the handler
+type Page struct {
+	Files      []*File      `json:"files"`
+	Violations []*Violation `json:"violations"`
+}
+
+// Handler renders the registered managers with their effective rules, and
+// the recent violations, as HTML or as JSON when requested with the
+// "format=json" query parameter or the "application/json" Accept header.
+// Only the validaters already built, by the validations or
+// Manager.BuildValidaters, are rendered, so that the options of the managers
+// can still be loaded.
+type Handler struct {
+	capacity   int
+	mu         sync.Mutex
+	violations []*Violation
+	next       int
+	remove     func()
+}
+
+// NewHandler returns a handler recording the violations, until closed
+func NewHandler(opts ...Option) *Handler {
+	h := &Handler{capacity: defaultCapacity}
+	for _, opt := range opts {
+		opt.apply(h)
+	}
+	if h.capacity > 0 {
+		h.remove = validate.AddViolationListener(h.record)
+	}
+	return h
+}
+
+// Close stops recording the violations
+func (h *Handler) Close() error {
+	if h.remove != nil {
+		h.remove()
+	}
+	return nil
+}
+
+func (h *Handler) record(ctx context.Context, err errors.ValidateError) {
+	v := &Violation{
+		Time:        time.Now(),
+		ProgramId:   err.GetProgramId(),
+		Expr:        err.GetExpr(),
+		Kind:        err.GetKind().String(),
+		Error:       err.Error(),
+		Explanation: err.GetExplanation().String(),
+	}
+	if desc := err.GetDescriptor(); desc != nil {
+		v.Descriptor = string(desc.FullName())
+	}
+	if attr := err.GetAttributeContext(); attr != nil && attr.Api != nil {
+		v.Operation = attr.Api.Operation
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.violations) < h.capacity {
+		h.violations = append(h.violations, v)
+	} else {
+		h.violations[h.next] = v
+	}
+	h.next = (h.next + 1) % h.capacity
+}
+
+// Violations returns the recent violations, most recent first
+func (h *Handler) Violations() []*Violation {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	violations := make([]*Violation, 0, len(h.violations))
+	for i := 1; i <= len(h.violations); i++ {
+		violations = append(violations, h.violations[(h.next-i+len(h.violations))%len(h.violations)])
+	}
+	return violations
+}
+
+// Page returns the content rendered by the handler
+func (h *Handler) Page() *Page {
+	page := &Page{Files: []*File{}, Violations: h.Violations()}
+	for _, m := range validate.Managers() {
+		page.Files = append(page.Files, &File{Path: m.File().Path(), Description: m.DescribeBuilt()})
+	}
+	return page
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	page := h.Page()
+	if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(page)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	pageTemplate.Execute(w, page)
+}
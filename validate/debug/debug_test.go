@@ -0,0 +1,131 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	testdata "github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestHandler(t *testing.T) {
+	m, err := validate.NewManager(testdata.File_testdata_validate_manager_proto, validate.WithConfiguration(&validate.Configuration{
+		Rule: &validate.FileRule{Options: &validate.Options{Globals: &validate.Options_Globals{Constants: map[string]string{"name_const": "name"}}}},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = m.BuildValidaters(); err != nil {
+		t.Fatal(err)
+	}
+	h := NewHandler(WithCapacity(2))
+	defer h.Close()
+	v, err := m.GetMessageRuleValidater((&testdata.ManagerRpcRequest{}).ProtoReflect().Descriptor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a", "name", "b", "c"} {
+		v.ValidateWithMask(context.Background(), &testdata.ManagerRpcRequest{Name: name}, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+	}
+	tests := []struct {
+		Name   string
+		Method string
+		Target string
+		Accept string
+		Want   int
+		Check  func(t *testing.T, body string)
+	}{
+		{
+			Name:   "JSON",
+			Method: http.MethodGet,
+			Target: "/?format=json",
+			Want:   http.StatusOK,
+			Check: func(t *testing.T, body string) {
+				page := &Page{}
+				if err := json.Unmarshal([]byte(body), page); err != nil {
+					t.Fatal(err)
+				}
+				if len(page.Files) != 1 || page.Files[0].Path != "testdata/validate/manager.proto" || page.Files[0].Description == nil {
+					t.Errorf("unexpected files: %s", body)
+				}
+				if len(page.Violations) != 2 || page.Violations[0].Error != page.Violations[1].Error || page.Violations[0].Time.Before(page.Violations[1].Time) {
+					t.Errorf("unexpected violations: %s", body)
+				} else if v := page.Violations[0]; v.Descriptor != "testdata.validate.ManagerRpcRequest" || v.Expr != "name == name_const" || v.Kind != "violation" {
+					t.Errorf("unexpected violation: %+v", v)
+				}
+				if !strings.Contains(body, `"source": "message_option"`) {
+					t.Errorf("missing source: %s", body)
+				}
+			},
+		},
+		{
+			Name:   "JSON (accept)",
+			Method: http.MethodGet,
+			Target: "/",
+			Accept: "application/json",
+			Want:   http.StatusOK,
+			Check: func(t *testing.T, body string) {
+				if !strings.HasPrefix(body, "{") {
+					t.Errorf("want JSON, got %s", body)
+				}
+			},
+		},
+		{
+			Name:   "HTML",
+			Method: http.MethodGet,
+			Target: "/",
+			Want:   http.StatusOK,
+			Check: func(t *testing.T, body string) {
+				for _, want := range []string{"<h2>testdata/validate/manager.proto</h2>", "<code>name == name_const</code>", "message_option", "testdata.validate.Manager.ManagerRpc"} {
+					if !strings.Contains(body, want) {
+						t.Errorf("missing %q in %s", want, body)
+					}
+				}
+			},
+		},
+		{
+			Name:   "Method not allowed",
+			Method: http.MethodPost,
+			Target: "/",
+			Want:   http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.Method, tt.Target, nil)
+			if tt.Accept != "" {
+				r.Header.Set("Accept", tt.Accept)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.Want {
+				t.Errorf("want %d, got %d", tt.Want, w.Code)
+			}
+			if tt.Check != nil {
+				tt.Check(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandlerUnbuiltManager(t *testing.T) {
+	m, err := validate.NewManager(testdata.File_testdata_validate_message_proto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewHandler()
+	defer h.Close()
+	for _, f := range h.Page().Files {
+		if f.Path == "testdata/validate/message.proto" && len(f.Description.Messages) > 0 {
+			t.Errorf("want no built validater, got %+v", f)
+		}
+	}
+	if err = m.LoadOptions(validate.WithCostLimit(100)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package debug
+
+import "html/template"
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Validation rules</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 2px 6px; text-align: left; vertical-align: top; }
+code { white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>Validation rules</h1>
+{{- range .Files}}
+<h2>{{.Path}}</h2>
+{{- with .Description}}
+<table>
+<tr><th>Element</th><th>Id</th><th>Expression</th><th>Source</th></tr>
+{{- range .Services}}
+{{- template "programs" .}}
+{{- range .Methods}}{{template "programs" .}}{{end}}
+{{- end}}
+{{- range .Messages}}
+{{- template "programs" .}}
+{{- range .Fields}}
+{{- template "programs" .}}
+{{- if .Required}}
+<tr><td>{{.Name}}</td><td></td><td>required</td><td>{{.RequiredSource}}</td></tr>
+{{- end}}
+{{- end}}
+{{- end}}
+</table>
+{{- end}}
+{{- else}}
+<p>No registered manager.</p>
+{{- end}}
+<h1>Recent violations</h1>
+{{- if .Violations}}
+<table>
+<tr><th>Time</th><th>Element</th><th>Operation</th><th>Id</th><th>Expression</th><th>Kind</th><th>Error</th></tr>
+{{- range .Violations}}
+<tr><td>{{.Time.Format "2006-01-02T15:04:05.000Z07:00"}}</td><td>{{.Descriptor}}</td><td>{{.Operation}}</td><td>{{.ProgramId}}</td><td><code>{{.Expr}}</code></td><td>{{.Kind}}</td><td>{{.Error}}{{if .Explanation}}<br><code>{{.Explanation}}</code>{{end}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No recent violation.</p>
+{{- end}}
+</body>
+</html>
+{{define "programs"}}
+{{- $name := .Name}}
+{{- range .Programs}}
+<tr><td>{{$name}}</td><td>{{.Id}}</td><td><code>{{.Expr}}</code></td><td>{{.Source}}</td></tr>
+{{- end}}
+{{- end}}
+`))
@@ -0,0 +1,181 @@
+package validate
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Source is the layer a program, or the required flag, comes from
+type Source int
+
+const (
+	SourceUnknown Source = iota
+	SourceConfiguration
+	SourceFileOption
+	SourceServiceOption
+	SourceMessageOption
+	SourceMethodOption
+	SourceFieldOption
+	SourceFieldBehavior
+	SourceResourceReference
+)
+
+var sourceNames = map[Source]string{
+	SourceUnknown:           "unknown",
+	SourceConfiguration:     "configuration",
+	SourceFileOption:        "file_option",
+	SourceServiceOption:     "service_option",
+	SourceMessageOption:     "message_option",
+	SourceMethodOption:      "method_option",
+	SourceFieldOption:       "field_option",
+	SourceFieldBehavior:     "field_behavior",
+	SourceResourceReference: "resource_reference",
+}
+
+func (s Source) String() string {
+	if name, ok := sourceNames[s]; ok {
+		return name
+	}
+	return sourceNames[SourceUnknown]
+}
+
+func (s Source) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Source) UnmarshalText(text []byte) error {
+	for source, name := range sourceNames {
+		if name == string(text) {
+			*s = source
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown source %q", text)
+}
+
+// ProgramDescription describes an effective program
+type ProgramDescription struct {
+	Id     string `json:"id,omitempty"`
+	Expr   string `json:"expr"`
+	Source Source `json:"source"`
+}
+
+// RuleDescription describes the effective rule of a method or a field
+type RuleDescription struct {
+	Name           protoreflect.FullName `json:"name"`
+	Programs       []*ProgramDescription `json:"programs,omitempty"`
+	Required       bool                  `json:"required,omitempty"`
+	RequiredSource Source                `json:"requiredSource,omitempty"`
+}
+
+type ServiceDescription struct {
+	Name     protoreflect.FullName `json:"name"`
+	Programs []*ProgramDescription `json:"programs,omitempty"`
+	Methods  []*RuleDescription    `json:"methods,omitempty"`
+}
+
+type MessageDescription struct {
+	Name     protoreflect.FullName `json:"name"`
+	Programs []*ProgramDescription `json:"programs,omitempty"`
+	Fields   []*RuleDescription    `json:"fields,omitempty"`
+}
+
+// FileDescription describes the effective rules of the services and messages
+// of a file, nested messages included
+type FileDescription struct {
+	Name     string                `json:"name"`
+	Services []*ServiceDescription `json:"services,omitempty"`
+	Messages []*MessageDescription `json:"messages,omitempty"`
+}
+
+// Describe builds the validaters of the file and describes their rules
+func (m *Manager) Describe() (*FileDescription, error) {
+	d := &FileDescription{Name: m.file.Path()}
+	for i := 0; i < m.file.Services().Len(); i++ {
+		v, err := m.GetServiceRuleValidater(m.file.Services().Get(i))
+		if err != nil {
+			return nil, err
+		}
+		d.Services = append(d.Services, v.Describe())
+	}
+	var err error
+	rangeMessageDescriptors(m.file.Messages(), func(md protoreflect.MessageDescriptor) {
+		if err != nil {
+			return
+		}
+		var v MessageRuleValidater
+		if v, err = m.GetMessageRuleValidater(md); err == nil {
+			d.Messages = append(d.Messages, v.Describe())
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// DescribeBuilt describes the rules of the validaters already built, without
+// building the others: the manager can still load libraries and options
+func (m *Manager) DescribeBuilt() *FileDescription {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	d := &FileDescription{Name: m.file.Path()}
+	for i := 0; i < m.file.Services().Len(); i++ {
+		if v := m.serviceValidaters[string(m.file.Services().Get(i).FullName())]; v.rv != nil {
+			d.Services = append(d.Services, v.rv.Describe())
+		}
+	}
+	rangeMessageDescriptors(m.file.Messages(), func(md protoreflect.MessageDescriptor) {
+		if v := m.messageValidaters[string(md.FullName())]; v.rv != nil {
+			d.Messages = append(d.Messages, v.rv.Describe())
+		}
+	})
+	return d
+}
+
+func (v *serviceRuleValidater) Describe() *ServiceDescription {
+	d := &ServiceDescription{Programs: describePrograms(v.ruleValidater)}
+	if v.desc == nil {
+		return d
+	}
+	d.Name = v.desc.FullName()
+	for i := 0; i < v.desc.Methods().Len(); i++ {
+		md := v.desc.Methods().Get(i)
+		rd := &RuleDescription{Name: md.FullName()}
+		if mv, ok := v.methodRulesValidaters[string(md.FullName())]; ok && mv != nil {
+			rd.Programs = describePrograms(mv.Validater())
+		}
+		d.Methods = append(d.Methods, rd)
+	}
+	return d
+}
+
+func (v *messageRuleValidater) Describe() *MessageDescription {
+	d := &MessageDescription{Programs: describePrograms(v.ruleValidater)}
+	if v.desc == nil {
+		return d
+	}
+	d.Name = v.desc.FullName()
+	for i := 0; i < v.desc.Fields().Len(); i++ {
+		fd := v.desc.Fields().Get(i)
+		rd := &RuleDescription{Name: fd.FullName()}
+		if fv, ok := v.fieldRulesValidaters[string(fd.Name())]; ok && fv != nil {
+			rd.Programs = describePrograms(fv.Validater())
+			rd.Required, rd.RequiredSource = fv.IsRequired(), fv.RequiredSource()
+		}
+		d.Fields = append(d.Fields, rd)
+	}
+	return d
+}
+
+func describePrograms(v RuleValidater) []*ProgramDescription {
+	if v == nil {
+		return nil
+	}
+	programs := []*ProgramDescription{}
+	for _, p := range v.Programs() {
+		programs = append(programs, &ProgramDescription{Id: p.Id, Expr: p.Expr, Source: p.Source})
+	}
+	return programs
+}
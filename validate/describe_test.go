@@ -0,0 +1,139 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestManagerDescribe(t *testing.T) {
+	config := &Configuration{Rule: &FileRule{
+		Options: &Options{Globals: &Options_Globals{Constants: map[string]string{"name_const": "name"}}},
+		ServiceRules: map[string]*ServiceRule{
+			"testdata.validate.Manager": {
+				Rule: &Rule{Programs: []*Rule_Program{{Id: "platform", Expr: `attribute_context.api.operation != ""`}}},
+			},
+		},
+		MessageRules: map[string]*MessageRule{
+			"testdata.validate.ManagerRpcRequest": {
+				FieldRules: map[string]*FieldRule{
+					"name": {Rule: &Rule{Programs: []*Rule_Program{{Id: "size", Expr: `size(name) < 64`}}}},
+				},
+			},
+		},
+	}}
+	m := newManager(validate.File_testdata_validate_manager_proto, WithConfiguration(config))
+	got, err := m.Describe()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &FileDescription{
+		Name: "testdata/validate/manager.proto",
+		Services: []*ServiceDescription{{
+			Name:     "testdata.validate.Manager",
+			Programs: []*ProgramDescription{{Id: "platform", Expr: `attribute_context.api.operation != ""`, Source: SourceConfiguration}},
+			Methods: []*RuleDescription{{
+				Name:     "testdata.validate.Manager.ManagerRpc",
+				Programs: []*ProgramDescription{{Expr: "request.name == name_const", Source: SourceMethodOption}},
+			}},
+		}},
+		Messages: []*MessageDescription{{
+			Name:     "testdata.validate.ManagerRpcRequest",
+			Programs: []*ProgramDescription{{Expr: "name == name_const", Source: SourceMessageOption}},
+			Fields: []*RuleDescription{{
+				Name:     "testdata.validate.ManagerRpcRequest.name",
+				Programs: []*ProgramDescription{{Id: "size", Expr: "size(name) < 64", Source: SourceConfiguration}},
+			}},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestMessageRuleValidaterDescribe(t *testing.T) {
+	tests := []struct {
+		Name               string
+		Config             *Configuration
+		Message            string
+		WantSources        []Source
+		WantRequiredSource Source
+	}{
+		{
+			Name:               "Required by field_behavior",
+			Config:             &Configuration{},
+			Message:            "FieldRequired",
+			WantRequiredSource: SourceFieldBehavior,
+		},
+		{
+			Name:    "Required support disabled",
+			Config:  &Configuration{RequiredSupportDisabled: true},
+			Message: "FieldRequired",
+		},
+		{
+			Name:        "Resource reference",
+			Config:      &Configuration{},
+			Message:     "FieldReferenceType",
+			WantSources: []Source{SourceResourceReference},
+		},
+		{
+			Name:        "Field option and max size",
+			Config:      &Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{"testdata.validate.FieldExpr": {FieldRules: map[string]*FieldRule{"name": {MaxSize: 64}}}}}},
+			Message:     "FieldExpr",
+			WantSources: []Source{SourceFieldOption},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			desc := validate.File_testdata_validate_field_proto.Messages().ByName(protoreflect.Name(tt.Message))
+			v, err := newManager(desc.ParentFile(), WithConfiguration(tt.Config)).GetMessageRuleValidater(desc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			field := v.Describe().Fields[0]
+			var sources []Source
+			for _, p := range field.Programs {
+				sources = append(sources, p.Source)
+			}
+			if !reflect.DeepEqual(sources, tt.WantSources) {
+				t.Errorf("want sources %v, got %v", tt.WantSources, sources)
+			}
+			if field.Required != (tt.WantRequiredSource != SourceUnknown) || field.RequiredSource != tt.WantRequiredSource {
+				t.Errorf("want required source %v, got %v", tt.WantRequiredSource, field.RequiredSource)
+			}
+		})
+	}
+}
+
+func TestManagerDescribeConfiguredProgramsMergedOnce(t *testing.T) {
+	config := &Configuration{Rule: &FileRule{
+		Options: &Options{Globals: &Options_Globals{Constants: map[string]string{"name_const": "name"}}},
+		ServiceRules: map[string]*ServiceRule{
+			"testdata.validate.Manager": {MethodRules: map[string]*MethodRule{
+				"ManagerRpc": {Rule: &Rule{Programs: []*Rule_Program{{Expr: `request.name != ""`}}}},
+			}},
+		},
+		MessageRules: map[string]*MessageRule{
+			"testdata.validate.ManagerRpcRequest": {FieldRules: map[string]*FieldRule{
+				"name": {Rule: &Rule{Programs: []*Rule_Program{{Expr: `name != ""`}}}},
+			}},
+		},
+	}}
+	got, err := newManager(validate.File_testdata_validate_manager_proto, WithConfiguration(config)).Describe()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantMethod := []*ProgramDescription{
+		{Expr: `request.name != ""`, Source: SourceConfiguration},
+		{Expr: "request.name == name_const", Source: SourceMethodOption},
+	}
+	if programs := got.Services[0].Methods[0].Programs; !reflect.DeepEqual(programs, wantMethod) {
+		t.Errorf("want method programs %+v, got %+v", wantMethod, programs)
+	}
+	wantField := []*ProgramDescription{{Expr: `name != ""`, Source: SourceConfiguration}}
+	if programs := got.Messages[0].Fields[0].Programs; !reflect.DeepEqual(programs, wantField) {
+		t.Errorf("want field programs %+v, got %+v", wantField, programs)
+	}
+}
@@ -1,11 +1,15 @@
 package errors
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter"
 	"google.golang.org/genproto/googleapis/rpc/context/attribute_context"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -22,11 +26,38 @@ type err interface {
 	Unwrap() error
 }
 
+// Kind describes why a validation did not succeed
+type Kind int
+
+const (
+	// KindViolation is used when a rule is not satisfied, or cannot be evaluated
+	KindViolation Kind = iota
+	// KindCostLimitExceeded is used when the evaluation exceeded its cost budget
+	KindCostLimitExceeded
+	// KindInterrupted is used when the evaluation was stopped by the context
+	KindInterrupted
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindCostLimitExceeded:
+		return "cost limit exceeded"
+	case KindInterrupted:
+		return "interrupted"
+	default:
+		return "violation"
+	}
+}
+
 type ValidateError interface {
 	err
 	GetAttributeContext() *attribute_context.AttributeContext
 	GetMessage() proto.Message
 	GetDescriptor() protoreflect.Descriptor
+	GetKind() Kind
+	GetProgramId() string
+	GetExpr() string
+	GetExplanation() *Explanation
 }
 
 func New(message proto.Message, desc protoreflect.Descriptor, ctx *attribute_context.AttributeContext) ValidateError {
@@ -34,7 +65,88 @@ func New(message proto.Message, desc protoreflect.Descriptor, ctx *attribute_con
 }
 
 func Wrap(err error, message proto.Message, desc protoreflect.Descriptor, ctx *attribute_context.AttributeContext) ValidateError {
-	return &validateError{Err: err, Message: message, Descriptor: desc, AttributeContext: ctx}
+	e := &validateError{Err: err, Message: message, Descriptor: desc, AttributeContext: ctx, Kind: kindOf(err)}
+	var vErr ValidateError
+	if errors.As(err, &vErr) {
+		e.ProgramId, e.Expr = vErr.GetProgramId(), vErr.GetExpr()
+		e.Explanation = vErr.GetExplanation()
+	}
+	return e
+}
+
+// WithProgram sets the program which failed, unless a nested validation
+// already reported its own
+func WithProgram(err ValidateError, id, expr string) ValidateError {
+	if e, ok := err.(*validateError); ok && e.ProgramId == "" && e.Expr == "" {
+		e.ProgramId, e.Expr = id, expr
+	}
+	return err
+}
+
+// Redacted replaces the values which must not be disclosed
+const Redacted = "[REDACTED]"
+
+// Explanation is the evaluation trace of a failed program
+type Explanation struct {
+	Expr  string             `json:"expr"`
+	Steps []*ExplanationStep `json:"steps"`
+}
+
+// ExplanationStep is the value of a sub-expression
+type ExplanationStep struct {
+	Expr  string `json:"expr"`
+	Value string `json:"value"`
+}
+
+// String renders the sub-expressions with their values, one per line
+func (e *Explanation) String() string {
+	if e == nil {
+		return ""
+	}
+	lines := make([]string, 0, len(e.Steps))
+	for _, s := range e.Steps {
+		lines = append(lines, s.Expr+" = "+s.Value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Redact hides the message of an evaluation error, which may disclose the
+// values of sensitive fields. Interruptions and validation errors are kept.
+func Redact(err error) error {
+	var vErr ValidateError
+	if err == nil || kindOf(err) != KindViolation || errors.As(err, &vErr) {
+		return err
+	}
+	return redactedError{}
+}
+
+type redactedError struct{}
+
+func (redactedError) Error() string { return "evaluation error: " + Redacted }
+
+// WithExplanation sets the explanation of the program which failed, unless a
+// nested validation already reported its own
+func WithExplanation(err ValidateError, explanation *Explanation) ValidateError {
+	if e, ok := err.(*validateError); ok && e.Explanation == nil {
+		e.Explanation = explanation
+	}
+	return err
+}
+
+func kindOf(err error) Kind {
+	var cancelled interpreter.EvalCancelledError
+	var vErr ValidateError
+	if errors.As(err, &vErr) {
+		return vErr.GetKind()
+	} else if errors.As(err, &cancelled) {
+		if cancelled.Cause == interpreter.CostLimitExceeded {
+			return KindCostLimitExceeded
+		}
+		return KindInterrupted
+	} else if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return KindInterrupted
+	}
+	return KindViolation
 }
 
 type validateError struct {
@@ -42,6 +154,10 @@ type validateError struct {
 	AttributeContext *attribute_context.AttributeContext
 	Message          proto.Message
 	Descriptor       protoreflect.Descriptor
+	Kind             Kind
+	ProgramId        string
+	Expr             string
+	Explanation      *Explanation
 }
 
 func (e *validateError) GetAttributeContext() *attribute_context.AttributeContext {
@@ -53,6 +169,19 @@ func (e *validateError) GetMessage() proto.Message {
 func (e *validateError) GetDescriptor() protoreflect.Descriptor {
 	return e.Descriptor
 }
+func (e *validateError) GetKind() Kind {
+	return e.Kind
+}
+func (e *validateError) GetProgramId() string {
+	return e.ProgramId
+}
+func (e *validateError) GetExpr() string {
+	return e.Expr
+}
+
+func (e *validateError) GetExplanation() *Explanation {
+	return e.Explanation
+}
 
 func (e *validateError) Error() string {
 	if e.Descriptor != nil {
@@ -0,0 +1,144 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/cel-go/interpreter"
+)
+
+func TestWrapKind(t *testing.T) {
+	tests := []struct {
+		Name string
+		Err  error
+		Want Kind
+	}{
+		{
+			Name: "Nil",
+			Want: KindViolation,
+		},
+		{
+			Name: "Violation",
+			Err:  fmt.Errorf("no such overload"),
+			Want: KindViolation,
+		},
+		{
+			Name: "Cost limit exceeded",
+			Err:  interpreter.EvalCancelledError{Cause: interpreter.CostLimitExceeded},
+			Want: KindCostLimitExceeded,
+		},
+		{
+			Name: "Interrupted",
+			Err:  interpreter.EvalCancelledError{Cause: interpreter.ContextCancelled},
+			Want: KindInterrupted,
+		},
+		{
+			Name: "Context deadline",
+			Err:  fmt.Errorf("resolver: %w", context.DeadlineExceeded),
+			Want: KindInterrupted,
+		},
+		{
+			Name: "Nested",
+			Err:  Wrap(interpreter.EvalCancelledError{Cause: interpreter.CostLimitExceeded}, nil, nil, nil),
+			Want: KindCostLimitExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			if got := Wrap(tt.Err, nil, nil, nil).GetKind(); got != tt.Want {
+				t.Errorf("want %v, got %v", tt.Want, got)
+			}
+		})
+	}
+}
+
+func TestWithProgram(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Err      ValidateError
+		WantId   string
+		WantExpr string
+	}{
+		{
+			Name:     "New",
+			Err:      WithProgram(New(nil, nil, nil), "id", "true"),
+			WantId:   "id",
+			WantExpr: "true",
+		},
+		{
+			Name:     "Nested",
+			Err:      WithProgram(Wrap(WithProgram(New(nil, nil, nil), "nested", "false"), nil, nil, nil), "id", "true"),
+			WantId:   "nested",
+			WantExpr: "false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			if tt.Err.GetProgramId() != tt.WantId || tt.Err.GetExpr() != tt.WantExpr {
+				t.Errorf("want %s (%s), got %s (%s)", tt.WantId, tt.WantExpr, tt.Err.GetProgramId(), tt.Err.GetExpr())
+			}
+		})
+	}
+}
+
+func TestWithExplanation(t *testing.T) {
+	explanation := &Explanation{Expr: "name != \"\"", Steps: []*ExplanationStep{{Expr: "name != \"\"", Value: "false"}, {Expr: "name", Value: Redacted}}}
+	tests := []struct {
+		Name string
+		Err  ValidateError
+		Want *Explanation
+	}{
+		{
+			Name: "New",
+			Err:  WithExplanation(New(nil, nil, nil), explanation),
+			Want: explanation,
+		},
+		{
+			Name: "Nested",
+			Err:  WithExplanation(Wrap(WithExplanation(New(nil, nil, nil), explanation), nil, nil, nil), &Explanation{}),
+			Want: explanation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			if got := tt.Err.GetExplanation(); got != tt.Want {
+				t.Errorf("want %v, got %v", tt.Want, got)
+			}
+		})
+	}
+	if want, got := "name != \"\" = false\nname = [REDACTED]", explanation.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Err      error
+		Redacted bool
+	}{
+		{
+			Name:     "Evaluation error",
+			Err:      fmt.Errorf(`error parsing regexp: "hunter2("`),
+			Redacted: true,
+		},
+		{
+			Name: "Interrupted",
+			Err:  context.Canceled,
+		},
+		{
+			Name: "Validation error",
+			Err:  New(nil, nil, nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			got := Redact(tt.Err)
+			if redacted := got != tt.Err; redacted != tt.Redacted || strings.Contains(got.Error(), "hunter2") {
+				t.Errorf("want redacted %v, got %v", tt.Redacted, got)
+			}
+		})
+	}
+}
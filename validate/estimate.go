@@ -0,0 +1,72 @@
+package validate
+
+import (
+	"strings"
+
+	"github.com/google/cel-go/checker"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// costEstimator provides the declared maximum sizes of the fields to the
+// static cost estimation, and bounds the estimated cost of the programs
+type costEstimator struct {
+	sizes   map[string]uint64
+	maxCost uint64
+}
+
+func (e *costEstimator) EstimateSize(element checker.AstNode) *checker.SizeEstimate {
+	if e == nil || len(element.Path()) == 0 {
+		return nil
+	}
+	if size, ok := e.sizes[strings.Join(element.Path(), ".")]; ok {
+		return &checker.SizeEstimate{Min: 0, Max: size}
+	}
+	return nil
+}
+
+func (e *costEstimator) EstimateCallCost(function, overloadID string, target *checker.AstNode, args []checker.AstNode) *checker.CallEstimate {
+	return nil
+}
+
+// fieldSizes returns the maximum sizes declared on the fields of the message,
+// keyed by their path in the rules
+func (b *builder) fieldSizes(desc protoreflect.MessageDescriptor, prefix string) map[string]uint64 {
+	sizes := map[string]uint64{}
+	for i := 0; i < desc.Fields().Len(); i++ {
+		fieldDesc := desc.Fields().Get(i)
+		if size := b.fieldMaxSize(fieldDesc); size > 0 {
+			sizes[prefix+string(fieldDesc.Name())] = size
+		}
+	}
+	return sizes
+}
+
+func (b *builder) fieldMaxSize(desc protoreflect.FieldDescriptor) uint64 {
+	var maxSize uint64
+	merge := func(mr *MessageRule) {
+		if mr != nil {
+			if fr, ok := mr.FieldRules[string(desc.Name())]; ok && fr.MaxSize > 0 {
+				maxSize = fr.MaxSize
+			}
+		}
+	}
+	if b.opts != nil && b.opts.Rule != nil {
+		merge(b.opts.Rule.MessageRules[string(desc.Parent().FullName())])
+	}
+	if fr := GetExtension(desc.ParentFile().Options(), E_File).(*FileRule); fr != nil {
+		merge(fr.MessageRules[string(desc.Parent().FullName())])
+	}
+	merge(GetExtension(desc.Parent().Options(), E_Message).(*MessageRule))
+	if fr := GetExtension(desc.Options(), E_Field).(*FieldRule); fr != nil && fr.MaxSize > 0 {
+		maxSize = fr.MaxSize
+	}
+	return maxSize
+}
+
+func (b *builder) newCostEstimator(sizes map[string]uint64) *costEstimator {
+	e := &costEstimator{sizes: sizes}
+	if b.opts != nil {
+		e.maxCost = b.opts.MaxEstimatedCost
+	}
+	return e
+}
@@ -0,0 +1,35 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestFieldMaxSize(t *testing.T) {
+	desc := (&validate.FieldReferenceType{}).ProtoReflect().Descriptor()
+	b := newBuilder()
+	b.opts = &Configuration{
+		Rule: &FileRule{
+			MessageRules: map[string]*MessageRule{
+				string(desc.FullName()): {
+					FieldRules: map[string]*FieldRule{"name": {MaxSize: 10}},
+				},
+			},
+		},
+	}
+	if size := b.fieldMaxSize(desc.Fields().ByName("name")); size != 10 {
+		t.Errorf("want max size 10, got %d", size)
+	}
+	v, err := b.BuildMessageRuleValidater(desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the max size is only a hint for the estimation
+	err = v.ValidateWithMask(context.Background(), &validate.FieldReferenceType{Name: "fields/field"}, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
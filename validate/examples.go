@@ -0,0 +1,128 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Example is a message, or a value of a field, declared valid or invalid by
+// the valid_examples and invalid_examples of a rule
+type Example struct {
+	// Descriptor is the message, or the field, of the example
+	Descriptor protoreflect.Descriptor
+	// Text is the message, or the value of the field, in the protobuf text
+	// format
+	Text  string
+	Valid bool
+	m     *Manager
+}
+
+func (e *Example) String() string {
+	if e.Valid {
+		return fmt.Sprintf("%s valid example %q", e.Descriptor.FullName(), e.Text)
+	}
+	return fmt.Sprintf("%s invalid example %q", e.Descriptor.FullName(), e.Text)
+}
+
+// Check validates the example, and returns an error if it does not behave as
+// declared. The example of a field is validated with the field as mask: as the
+// default value of a field is only checked when required, it cannot be an
+// invalid example otherwise.
+func (e *Example) Check(ctx context.Context) error {
+	var md protoreflect.MessageDescriptor
+	text, fm := e.Text, &fieldmaskpb.FieldMask{Paths: []string{"*"}}
+	switch desc := e.Descriptor.(type) {
+	case protoreflect.MessageDescriptor:
+		md = desc
+	case protoreflect.FieldDescriptor:
+		md = desc.ContainingMessage()
+		text, fm = fmt.Sprintf("%s: %s", desc.TextName(), e.Text), &fieldmaskpb.FieldMask{Paths: []string{desc.TextName()}}
+	default:
+		return fmt.Errorf("%s: unsupported descriptor", e)
+	}
+	m := newExampleMessage(md)
+	if err := prototext.Unmarshal([]byte(text), m); err != nil {
+		return fmt.Errorf("%s: %w", e, err)
+	}
+	v, err := e.m.GetMessageRuleValidater(md)
+	if err != nil {
+		return fmt.Errorf("%s: %w", e, err)
+	}
+	if err = v.ValidateWithMask(ctx, m, fm); e.Valid && err != nil {
+		return fmt.Errorf("%s is not valid: %w", e, err)
+	} else if !e.Valid && err == nil {
+		if fd, ok := e.Descriptor.(protoreflect.FieldDescriptor); ok && IsDefaultValue(m, fd) {
+			return fmt.Errorf("%s is the default value of the field, on which its programs are not evaluated", e)
+		}
+		return fmt.Errorf("%s is valid", e)
+	}
+	return nil
+}
+
+// newExampleMessage returns a message of the registered type when the
+// descriptor is the registered one, or a dynamic message otherwise
+func newExampleMessage(md protoreflect.MessageDescriptor) proto.Message {
+	if mt, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName()); err == nil && mt.Descriptor() == md {
+		return mt.New().Interface()
+	}
+	return dynamicpb.NewMessage(md)
+}
+
+// Examples returns the examples of the messages of the file, nested ones
+// included, and of their fields, from the configuration and the options
+func (m *Manager) Examples() []*Example {
+	b := m.builder()
+	var configRule, fileRule *FileRule
+	if b.opts != nil {
+		configRule = b.opts.Rule
+	}
+	fileRule = GetExtension(m.file.Options(), E_File).(*FileRule)
+	examples := []*Example{}
+	add := func(desc protoreflect.Descriptor, valid, invalid []string) {
+		for _, text := range valid {
+			examples = append(examples, &Example{Descriptor: desc, Text: text, Valid: true, m: m})
+		}
+		for _, text := range invalid {
+			examples = append(examples, &Example{Descriptor: desc, Text: text, m: m})
+		}
+	}
+	rangeMessageDescriptors(m.file.Messages(), func(md protoreflect.MessageDescriptor) {
+		name := string(md.FullName())
+		messageRules := []*MessageRule{
+			configRule.GetMessageRules()[name],
+			fileRule.GetMessageRules()[name],
+			GetExtension(md.Options(), E_Message).(*MessageRule),
+		}
+		for _, mr := range messageRules {
+			add(md, mr.GetValidExamples(), mr.GetInvalidExamples())
+		}
+		for i := 0; i < md.Fields().Len(); i++ {
+			fd := md.Fields().Get(i)
+			for _, mr := range messageRules {
+				fr := mr.GetFieldRules()[string(fd.Name())]
+				add(fd, fr.GetValidExamples(), fr.GetInvalidExamples())
+			}
+			fr := GetExtension(fd.Options(), E_Field).(*FieldRule)
+			add(fd, fr.GetValidExamples(), fr.GetInvalidExamples())
+		}
+	})
+	return examples
+}
+
+// CheckExamples checks the examples of the file, and returns the error of the
+// first one which does not behave as declared
+func (m *Manager) CheckExamples(ctx context.Context) error {
+	for _, e := range m.Examples() {
+		if err := e.Check(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
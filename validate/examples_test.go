@@ -0,0 +1,108 @@
+package validate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestCheckExamples(t *testing.T) {
+	sensitiveRules := func(rule *FieldRule) map[string]*MessageRule {
+		return map[string]*MessageRule{
+			"testdata.validate.MessageSensitive": {FieldRules: map[string]*FieldRule{"name": rule}},
+		}
+	}
+	nameRule := &Rule{Programs: []*Rule_Program{{Expr: `name.size() < 4`}}}
+	tests := []struct {
+		Name         string
+		MessageRules map[string]*MessageRule
+		WantCount    int
+		WantErr      bool
+	}{
+		{
+			Name:         "Message examples",
+			MessageRules: map[string]*MessageRule{"testdata.validate.MessageExpr": {ValidExamples: []string{`name: "name"`}, InvalidExamples: []string{``}}},
+			WantCount:    2,
+		},
+		{
+			Name:         "Valid message example failing",
+			MessageRules: map[string]*MessageRule{"testdata.validate.MessageExpr": {ValidExamples: []string{``}}},
+			WantCount:    1,
+			WantErr:      true,
+		},
+		{
+			Name:         "Invalid message example passing",
+			MessageRules: map[string]*MessageRule{"testdata.validate.MessageExpr": {InvalidExamples: []string{`name: "name"`}}},
+			WantCount:    1,
+			WantErr:      true,
+		},
+		{
+			Name:         "Malformed example",
+			MessageRules: map[string]*MessageRule{"testdata.validate.MessageExpr": {ValidExamples: []string{`unknown: 1`}}},
+			WantCount:    1,
+			WantErr:      true,
+		},
+		{
+			Name:         "Nested message examples",
+			MessageRules: map[string]*MessageRule{"testdata.validate.MessageNestedExpr": {ValidExamples: []string{`message_expr { name: "name" }`}, InvalidExamples: []string{`message_expr {}`}}},
+			WantCount:    2,
+		},
+		{
+			Name:         "Field examples",
+			MessageRules: sensitiveRules(&FieldRule{Rule: nameRule, ValidExamples: []string{`"abc"`}, InvalidExamples: []string{`"abcd"`}}),
+			WantCount:    2,
+		},
+		{
+			Name:         "Field example ignoring other fields",
+			MessageRules: sensitiveRules(&FieldRule{ValidExamples: []string{`"abcd"`}}),
+			WantCount:    1,
+		},
+		{
+			Name:         "Invalid field example passing",
+			MessageRules: sensitiveRules(&FieldRule{Rule: nameRule, InvalidExamples: []string{`"abc"`}}),
+			WantCount:    1,
+			WantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			m := newManager(validate.File_testdata_validate_message_proto, WithConfiguration(&Configuration{Rule: &FileRule{MessageRules: tt.MessageRules}}))
+			if got := len(m.Examples()); got != tt.WantCount {
+				t.Errorf("want %d examples, got %d", tt.WantCount, got)
+			}
+			if err := m.CheckExamples(context.Background()); (err != nil) != tt.WantErr {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckExamplesDefaultValue(t *testing.T) {
+	m := newManager(validate.File_testdata_validate_message_proto, WithConfiguration(&Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+		"testdata.validate.MessageSensitive": {FieldRules: map[string]*FieldRule{"name": {
+			Rule:            &Rule{Programs: []*Rule_Program{{Expr: `name != ""`}}},
+			InvalidExamples: []string{`""`},
+		}}},
+	}}}))
+	if err := m.CheckExamples(context.Background()); err == nil || !strings.Contains(err.Error(), "default value") {
+		t.Errorf("want default value error, got %v", err)
+	}
+}
+
+func TestCheckExamplesDynamic(t *testing.T) {
+	fdp := protodesc.ToFileDescriptorProto(validate.File_testdata_validate_message_proto)
+	desc, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m := newManager(desc, WithConfiguration(&Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+		"testdata.validate.MessageExpr": {ValidExamples: []string{`name: "name"`}, InvalidExamples: []string{``}},
+	}}}))
+	if err := m.CheckExamples(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
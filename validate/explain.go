@@ -0,0 +1,270 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/google/cel-go/parser"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate/errors"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxExplainedElements is the number of elements of a list or a map rendered
+// in an explanation
+const maxExplainedElements = 10
+
+// WithExplain enables the explanation of the programs which are not
+// satisfied: they are evaluated again, tracking the values of their
+// sub-expressions, which are attached to the error. The values depending on a
+// sensitive field are redacted.
+func WithExplain() ManagerOption {
+	return managerOption(func(b *builder) {
+		b.explain = true
+	})
+}
+
+// explainer evaluates a program again, tracking the values of its
+// sub-expressions. It is prepared on the first explanation.
+type explainer struct {
+	env       *cel.Env
+	ast       *cel.Ast
+	opts      []cel.ProgramOption
+	desc      protoreflect.Descriptor
+	sensitive func(fd protoreflect.FieldDescriptor) bool
+	once      sync.Once
+	program   cel.Program
+	steps     []*explainStep
+	err       error
+}
+
+type explainStep struct {
+	id       int64
+	expr     string
+	redacted bool
+}
+
+// explain returns the explanation of the program, or nil if it cannot be
+// explained
+func (p *ValidateProgram) explain(ctx context.Context, vars map[string]interface{}) *errors.Explanation {
+	x := p.explainer
+	if x == nil {
+		return nil
+	}
+	x.once.Do(x.prepare)
+	if x.err != nil {
+		return nil
+	}
+	_, details, _ := x.program.ContextEval(ctx, vars)
+	if details == nil || details.State() == nil {
+		return nil
+	}
+	explanation := &errors.Explanation{Expr: p.Expr, Steps: []*errors.ExplanationStep{}}
+	for i, s := range x.steps {
+		if val, ok := details.State().Value(s.id); ok {
+			step := &errors.ExplanationStep{Expr: s.expr, Value: errors.Redacted}
+			// the result of the program is not sensitive, unless it is an error
+			if !s.redacted || (i == 0 && types.IsBool(val)) {
+				step.Value = formatValue(val)
+			}
+			explanation.Steps = append(explanation.Steps, step)
+		}
+	}
+	return explanation
+}
+
+func (x *explainer) prepare() {
+	opts := append([]cel.ProgramOption{cel.EvalOptions(cel.OptTrackState)}, x.opts...)
+	if x.program, x.err = x.env.Program(x.ast, opts...); x.err != nil {
+		return
+	}
+	checked, err := cel.AstToCheckedExpr(x.ast)
+	if err != nil {
+		x.err = err
+		return
+	}
+	w := newExplainWalker(checked, x.desc, x.sensitive)
+	w.walk(checked.Expr, true)
+	x.steps = w.steps
+}
+
+// explainWalker collects the sub-expressions of a checked expression, and
+// whether they depend on a sensitive field
+type explainWalker struct {
+	checked   *exprpb.CheckedExpr
+	desc      protoreflect.Descriptor
+	fields    protoreflect.FieldDescriptors
+	sensitive func(fd protoreflect.FieldDescriptor) bool
+	messages  map[string]protoreflect.MessageDescriptor
+	seen      map[string]bool
+	steps     []*explainStep
+}
+
+// newExplainWalker returns a walker of the expression evaluated on behalf of
+// the element desc, whose identifiers are the fields of the message, if any
+func newExplainWalker(checked *exprpb.CheckedExpr, desc protoreflect.Descriptor, sensitive func(fd protoreflect.FieldDescriptor) bool) *explainWalker {
+	w := &explainWalker{checked: checked, desc: desc, sensitive: sensitive}
+	switch d := desc.(type) {
+	case protoreflect.MessageDescriptor:
+		w.fields = d.Fields()
+	case protoreflect.FieldDescriptor:
+		w.fields = d.ContainingMessage().Fields()
+	}
+	return w
+}
+
+// message returns the message of the file of the element, or of its imports,
+// with the name
+func (w *explainWalker) message(name string) (protoreflect.MessageDescriptor, bool) {
+	if w.messages == nil {
+		w.messages, w.seen = map[string]protoreflect.MessageDescriptor{}, map[string]bool{}
+		if w.desc != nil {
+			w.addFile(w.desc.ParentFile())
+		}
+	}
+	md, ok := w.messages[name]
+	return md, ok
+}
+
+// addFile adds the messages of the file and of its imports, which the
+// expression can select fields of
+func (w *explainWalker) addFile(fd protoreflect.FileDescriptor) {
+	if w.seen[fd.Path()] {
+		return
+	}
+	w.seen[fd.Path()] = true
+	rangeMessageDescriptors(fd.Messages(), func(md protoreflect.MessageDescriptor) {
+		w.messages[string(md.FullName())] = md
+	})
+	for i := 0; i < fd.Imports().Len(); i++ {
+		w.addFile(fd.Imports().Get(i).FileDescriptor)
+	}
+}
+
+// walk collects the sub-expressions in pre-order, skipping the constants and
+// the steps of the comprehensions, and reports whether the expression depends
+// on a sensitive field
+func (w *explainWalker) walk(e *exprpb.Expr, collect bool) bool {
+	var step *explainStep
+	if collect && e.GetConstExpr() == nil {
+		if text, err := parser.Unparse(e, w.checked.SourceInfo); err == nil && !w.collected(text) {
+			step = &explainStep{id: e.Id, expr: text}
+			w.steps = append(w.steps, step)
+		}
+	}
+	sensitive := false
+	walk := func(sub *exprpb.Expr, collect bool) {
+		if sub != nil && w.walk(sub, collect) {
+			sensitive = true
+		}
+	}
+	switch k := e.ExprKind.(type) {
+	case *exprpb.Expr_IdentExpr:
+		if w.fields != nil && w.sensitive != nil {
+			if fd := w.fields.ByTextName(k.IdentExpr.Name); fd != nil && w.sensitive(fd) {
+				sensitive = true
+			}
+		}
+	case *exprpb.Expr_SelectExpr:
+		// the values of the operands of a field selection are not tracked
+		walk(k.SelectExpr.Operand, false)
+		if name := w.checked.TypeMap[k.SelectExpr.Operand.Id].GetMessageType(); name != "" && w.sensitive != nil {
+			if md, ok := w.message(name); ok {
+				if fd := md.Fields().ByTextName(k.SelectExpr.Field); fd != nil && w.sensitive(fd) {
+					sensitive = true
+				}
+			}
+		}
+	case *exprpb.Expr_CallExpr:
+		walk(k.CallExpr.Target, collect)
+		for _, arg := range k.CallExpr.Args {
+			walk(arg, collect)
+		}
+	case *exprpb.Expr_ListExpr:
+		for _, elem := range k.ListExpr.Elements {
+			walk(elem, collect)
+		}
+	case *exprpb.Expr_StructExpr:
+		for _, entry := range k.StructExpr.Entries {
+			walk(entry.GetMapKey(), collect)
+			walk(entry.Value, collect)
+		}
+	case *exprpb.Expr_ComprehensionExpr:
+		c := k.ComprehensionExpr
+		walk(c.IterRange, collect)
+		for _, sub := range []*exprpb.Expr{c.AccuInit, c.LoopCondition, c.LoopStep, c.Result} {
+			walk(sub, false)
+		}
+	}
+	if step != nil {
+		step.redacted = sensitive
+	}
+	return sensitive
+}
+
+func (w *explainWalker) collected(text string) bool {
+	for _, s := range w.steps {
+		if s.expr == text {
+			return true
+		}
+	}
+	return false
+}
+
+// formatValue renders a value with the CEL syntax, messages being reduced to
+// their type
+func formatValue(val ref.Val) string {
+	switch {
+	case types.IsError(val):
+		return fmt.Sprintf("error: %v", val.Value())
+	case types.IsUnknown(val):
+		return "unknown"
+	}
+	switch v := val.(type) {
+	case types.String:
+		return strconv.Quote(string(v))
+	case types.Bytes:
+		return fmt.Sprintf("b%q", []byte(v))
+	case types.Uint:
+		return fmt.Sprintf("%du", uint64(v))
+	case types.Null:
+		return "null"
+	case traits.Mapper:
+		entries := []string{}
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			key := it.Next()
+			entries = append(entries, formatValue(key)+": "+formatValue(v.Get(key)))
+		}
+		sort.Strings(entries)
+		return "{" + joinElements(entries) + "}"
+	case traits.Lister:
+		elems := []string{}
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			elems = append(elems, formatValue(it.Next()))
+		}
+		return "[" + joinElements(elems) + "]"
+	}
+	if m, ok := val.Value().(proto.Message); ok {
+		return string(m.ProtoReflect().Descriptor().FullName()) + "{...}"
+	}
+	if s, ok := val.ConvertToType(types.StringType).(types.String); ok {
+		return string(s)
+	}
+	return fmt.Sprint(val.Value())
+}
+
+func joinElements(elems []string) string {
+	if len(elems) > maxExplainedElements {
+		elems = append(elems[:maxExplainedElements:maxExplainedElements], "...")
+	}
+	return strings.Join(elems, ", ")
+}
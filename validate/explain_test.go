@@ -0,0 +1,101 @@
+package validate
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Expr      string
+		Message   proto.Message
+		WantSteps []*errors.ExplanationStep
+	}{
+		{
+			Name:    "Values",
+			Expr:    `name.size() > 3 && name != "test"`,
+			Message: &validate.MessageSensitive{Name: "ab"},
+			WantSteps: []*errors.ExplanationStep{
+				{Expr: `name.size() > 3 && name != "test"`, Value: "false"},
+				{Expr: "name.size() > 3", Value: "false"},
+				{Expr: "name.size()", Value: "2"},
+				{Expr: "name", Value: `"ab"`},
+			},
+		},
+		{
+			Name:    "Sensitive field",
+			Expr:    `password.size() >= 8`,
+			Message: &validate.MessageSensitive{Password: "secret"},
+			WantSteps: []*errors.ExplanationStep{
+				{Expr: "password.size() >= 8", Value: "false"},
+				{Expr: "password.size()", Value: errors.Redacted},
+				{Expr: "password", Value: errors.Redacted},
+			},
+		},
+		{
+			Name:    "Sensitive nested field",
+			Expr:    `nested.label == nested.secret`,
+			Message: &validate.MessageSensitive{Nested: &validate.MessageSensitiveNested{Label: "label", Secret: "secret"}},
+			WantSteps: []*errors.ExplanationStep{
+				{Expr: "nested.label == nested.secret", Value: "false"},
+				{Expr: "nested.label", Value: `"label"`},
+				{Expr: "nested.secret", Value: errors.Redacted},
+			},
+		},
+		{
+			Name:    "Sensitive repeated field",
+			Expr:    `tokens.all(t, t.size() > 4) || name == ""`,
+			Message: &validate.MessageSensitive{Name: "name", Tokens: []string{"abcdef", "abc"}},
+			WantSteps: []*errors.ExplanationStep{
+				{Expr: `tokens.all(t, t.size() > 4) || name == ""`, Value: "false"},
+				{Expr: "tokens.all(t, t.size() > 4)", Value: errors.Redacted},
+				{Expr: "tokens", Value: errors.Redacted},
+				{Expr: `name == ""`, Value: "false"},
+				{Expr: "name", Value: `"name"`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			config := &Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+				"testdata.validate.MessageSensitive": {Rule: &Rule{Programs: []*Rule_Program{{Id: "explained", Expr: tt.Expr}}}},
+			}}}
+			desc := tt.Message.ProtoReflect().Descriptor()
+			v, err := newManager(desc.ParentFile(), WithConfiguration(config), WithExplain()).GetMessageRuleValidater(desc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = v.ValidateWithMask(context.Background(), tt.Message, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+			vErr, ok := err.(errors.ValidateError)
+			if !ok {
+				t.Fatalf("want validate error, got %v", err)
+			}
+			explanation := vErr.GetExplanation()
+			if explanation == nil || explanation.Expr != tt.Expr {
+				t.Fatalf("want explanation of %s, got %+v", tt.Expr, explanation)
+			}
+			if !reflect.DeepEqual(explanation.Steps, tt.WantSteps) {
+				t.Errorf("want:\n%s\ngot:\n%s", (&errors.Explanation{Steps: tt.WantSteps}).String(), explanation.String())
+			}
+		})
+	}
+}
+
+func TestExplainDisabled(t *testing.T) {
+	desc := validate.File_testdata_validate_message_proto.Messages().ByName("MessageExpr")
+	v, err := newManager(desc.ParentFile(), WithConfiguration(&Configuration{})).GetMessageRuleValidater(desc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = v.ValidateWithMask(context.Background(), &validate.MessageExpr{}, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+	if vErr, ok := err.(errors.ValidateError); !ok || vErr.GetExplanation() != nil {
+		t.Errorf("want error without explanation, got %v", err)
+	}
+}
@@ -15,7 +15,31 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
-func NewGRPCUnaryInterceptor(validater validate.ServiceRuleValidater, errorHandler func(err error) error) grpc.UnaryServerInterceptor {
+type InterceptorOption interface {
+	apply(o *interceptorOptions)
+}
+
+type interceptorOptions struct {
+	observer validate.Observer
+}
+
+type interceptorOption func(o *interceptorOptions)
+
+func (opt interceptorOption) apply(o *interceptorOptions) { opt(o) }
+
+// WithObserver notifies the observer of the validations of the calls, in
+// addition to the observer of the manager
+func WithObserver(observer validate.Observer) InterceptorOption {
+	return interceptorOption(func(o *interceptorOptions) {
+		o.observer = observer
+	})
+}
+
+func NewGRPCUnaryInterceptor(validater validate.ServiceRuleValidater, errorHandler func(err error) error, opts ...InterceptorOption) grpc.UnaryServerInterceptor {
+	options := &interceptorOptions{}
+	for _, opt := range opts {
+		opt.apply(options)
+	}
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		attr := &attribute_context.AttributeContext{
 			Api: &attribute_context.AttributeContext_Api{
@@ -49,7 +73,11 @@ func NewGRPCUnaryInterceptor(validater validate.ServiceRuleValidater, errorHandl
 			attr.Origin = pr
 			attr.Source = pr
 		}
-		if err := validater.Validate(ctx, attr, req.(proto.Message)); err != nil {
+		validateCtx := ctx
+		if options.observer != nil {
+			validateCtx = validate.ContextWithObserver(ctx, options.observer)
+		}
+		if err := validater.Validate(validateCtx, attr, req.(proto.Message)); err != nil {
 			if errorHandler != nil {
 				return nil, errorHandler(err)
 			}
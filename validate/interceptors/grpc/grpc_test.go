@@ -133,3 +133,34 @@ func TestNewGRPCUnaryInterceptor(t *testing.T) {
 		})
 	}
 }
+
+type validationObserver struct {
+	events []*validate.ValidationEvent
+}
+
+func (o *validationObserver) ObserveProgram(ctx context.Context, event *validate.ProgramEvent) {}
+
+func (o *validationObserver) ObserveValidation(ctx context.Context, event *validate.ValidationEvent) {
+	o.events = append(o.events, event)
+}
+
+func TestNewGRPCUnaryInterceptorObserver(t *testing.T) {
+	desc := testdata.File_testdata_validate_service_proto.Services().ByName("ServiceExpr")
+	manager, err := validate.NewManager(desc.ParentFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	validater, err := manager.GetServiceRuleValidater(desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := &validationObserver{}
+	interceptor := NewGRPCUnaryInterceptor(validater, nil, WithObserver(o))
+	info := &grpc.UnaryServerInfo{FullMethod: "/testdata.validate.ServiceExpr/Rpc"}
+	if _, err := interceptor(context.Background(), &emptypb.Empty{}, info, func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }); err == nil {
+		t.Fatal("want error")
+	}
+	if len(o.events) != 1 || o.events[0].Outcome != validate.OutcomeFailed || o.events[0].Descriptor.FullName() != "testdata.validate.ServiceExpr.Rpc" {
+		t.Errorf("unexpected events: %v", o.events)
+	}
+}
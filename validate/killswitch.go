@@ -0,0 +1,136 @@
+package validate
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DisabledProgramsEnv is the environment variable listing the programs
+// disabled at startup, as comma separated patterns
+const DisabledProgramsEnv = "CEL_VALIDATE_DISABLED_PROGRAMS"
+
+var disabledPrograms atomic.Pointer[programSwitch]
+
+func init() {
+	if err := setDisabledProgramsEnv(os.Getenv(DisabledProgramsEnv)); err != nil {
+		fmt.Fprintf(os.Stderr, "validate: ignoring %s: %v\n", DisabledProgramsEnv, err)
+	}
+}
+
+func setDisabledProgramsEnv(env string) error {
+	if env == "" {
+		return nil
+	}
+	return SetDisabledPrograms(strings.Split(env, ",")...)
+}
+
+// SetDisabledPrograms replaces the programs skipped at runtime by every
+// manager. A pattern is either "element:id" or "id", where element is a glob
+// matching the full name of the service, method, message or field the program
+// applies to, and id a glob matching the program id. In the globs, "*" matches
+// any characters, "/" included, and "?" a single one. As full names cannot
+// contain ":", the element ends at the first one: an id containing ":" must be
+// preceded by an element, e.g. "*:urn:id". The service programs are matched
+// against the full name of the called method too, so that they can be disabled
+// for a single method.
+func SetDisabledPrograms(patterns ...string) error {
+	s, err := newProgramSwitch(patterns)
+	if err != nil {
+		return err
+	}
+	disabledPrograms.Store(s)
+	return nil
+}
+
+// DisabledPrograms returns the patterns of the programs skipped at runtime
+func DisabledPrograms() []string {
+	if s := disabledPrograms.Load(); s != nil {
+		return append([]string{}, s.raw...)
+	}
+	return nil
+}
+
+type programPattern struct {
+	element string
+	id      string
+}
+
+// programSwitch matches the disabled programs
+type programSwitch struct {
+	raw      []string
+	patterns []programPattern
+}
+
+func newProgramSwitch(patterns []string) (*programSwitch, error) {
+	s := &programSwitch{}
+	for _, raw := range patterns {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		p := programPattern{element: "*", id: raw}
+		if i := strings.Index(raw, ":"); i >= 0 {
+			p.element, p.id = raw[:i], raw[i+1:]
+		}
+		if strings.ContainsAny(raw, `[]\`) {
+			return nil, fmt.Errorf("invalid disabled program pattern %q: character classes and escapes are not supported", raw)
+		}
+		for _, r := range p.element {
+			if !isFullNameRune(r) && r != '*' && r != '?' {
+				return nil, fmt.Errorf("invalid disabled program pattern %q: %q cannot be part of a full name, prefix an id containing \":\" with \"*:\"", raw, r)
+			}
+		}
+		s.raw = append(s.raw, raw)
+		s.patterns = append(s.patterns, p)
+	}
+	if len(s.patterns) == 0 {
+		return nil, nil
+	}
+	return s, nil
+}
+
+func (s *programSwitch) disabled(element protoreflect.FullName, id string) bool {
+	if s == nil {
+		return false
+	}
+	for _, p := range s.patterns {
+		if matchGlob(p.element, string(element)) && matchGlob(p.id, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func isFullNameRune(r rune) bool {
+	return r == '.' || r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9')
+}
+
+// matchGlob reports whether s matches the pattern, where "*" matches any
+// sequence of characters and "?" a single character
+func matchGlob(pattern, s string) bool {
+	p, str := []rune(pattern), []rune(s)
+	pi, si := 0, 0
+	star, next := -1, 0
+	for si < len(str) {
+		switch {
+		case pi < len(p) && (p[pi] == '?' || p[pi] == str[si]):
+			pi, si = pi+1, si+1
+		case pi < len(p) && p[pi] == '*':
+			star, next = pi, si
+			pi++
+		case star >= 0:
+			next++
+			pi, si = star+1, next
+		default:
+			return false
+		}
+	}
+	for pi < len(p) && p[pi] == '*' {
+		pi++
+	}
+	return pi == len(p)
+}
@@ -0,0 +1,203 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"google.golang.org/genproto/googleapis/rpc/context/attribute_context"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestDisabledPrograms(t *testing.T) {
+	tests := []struct {
+		Name         string
+		Patterns     []string
+		Config       []string
+		WantErr      bool
+		WantBuildErr bool
+		WantSkipped  bool
+	}{
+		{
+			Name: "Enabled",
+		},
+		{
+			Name:        "Id",
+			Patterns:    []string{"override"},
+			WantSkipped: true,
+		},
+		{
+			Name:        "Element and id glob",
+			Patterns:    []string{"testdata.validate.Message*:over*"},
+			WantSkipped: true,
+		},
+		{
+			Name:     "Other element",
+			Patterns: []string{"testdata.validate.Other:override"},
+		},
+		{
+			Name:     "Invalid pattern",
+			Patterns: []string{"override["},
+			WantErr:  true,
+		},
+		{
+			Name:        "Configuration",
+			Config:      []string{"testdata.validate.MessageOverride:override"},
+			WantSkipped: true,
+		},
+		{
+			Name:         "Invalid configuration",
+			Config:       []string{"[:override"},
+			WantBuildErr: true,
+		},
+	}
+	desc := validate.File_testdata_validate_message_proto.Messages().ByName("MessageOverride")
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			defer SetDisabledPrograms()
+			if err := SetDisabledPrograms(tt.Patterns...); (err != nil) != tt.WantErr {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			}
+			v, err := newManager(desc.ParentFile(), WithConfiguration(&Configuration{DisabledPrograms: tt.Config})).GetMessageRuleValidater(desc)
+			if (err != nil) != tt.WantBuildErr {
+				t.Fatalf("wantBuildErr %v, got %v", tt.WantBuildErr, err)
+			} else if err != nil {
+				return
+			}
+			skipped := []string{}
+			remove := AddSkipListener(func(ctx context.Context, desc protoreflect.Descriptor, program *ValidateProgram) {
+				skipped = append(skipped, string(desc.FullName())+":"+program.Id)
+			})
+			defer remove()
+			err = v.ValidateWithMask(context.Background(), &validate.MessageOverride{}, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+			if (err == nil) != tt.WantSkipped {
+				t.Errorf("wantSkipped %v, got %v", tt.WantSkipped, err)
+			}
+			if tt.WantSkipped && (len(skipped) != 1 || skipped[0] != "testdata.validate.MessageOverride:override") {
+				t.Errorf("unexpected skipped programs: %v", skipped)
+			}
+		})
+	}
+}
+
+func TestDisabledServicePrograms(t *testing.T) {
+	config := &Configuration{Rule: &FileRule{ServiceRules: map[string]*ServiceRule{
+		"testdata.validate.Manager": {Rule: &Rule{Programs: []*Rule_Program{{Id: "deny", Expr: `false`}}}},
+	}}}
+	tests := []struct {
+		Name        string
+		Patterns    []string
+		WantSkipped bool
+	}{
+		{
+			Name: "Enabled",
+		},
+		{
+			Name:        "Service",
+			Patterns:    []string{"testdata.validate.Manager:deny"},
+			WantSkipped: true,
+		},
+		{
+			Name:        "Method",
+			Patterns:    []string{"testdata.validate.Manager.ManagerRpc:deny"},
+			WantSkipped: true,
+		},
+		{
+			Name:     "Other method",
+			Patterns: []string{"testdata.validate.Manager.Other:deny"},
+		},
+	}
+	desc := validate.File_testdata_validate_manager_proto.Services().ByName("Manager")
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			defer SetDisabledPrograms()
+			if err := SetDisabledPrograms(tt.Patterns...); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			v, err := newManager(desc.ParentFile(), WithConfiguration(config, nameConfiguration("name"))).GetServiceRuleValidater(desc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			attr := &attribute_context.AttributeContext{Api: &attribute_context.AttributeContext_Api{Operation: "testdata.validate.Manager.ManagerRpc"}}
+			if err = v.Validate(context.Background(), attr, &validate.ManagerRpcRequest{Name: "name"}); (err == nil) != tt.WantSkipped {
+				t.Errorf("wantSkipped %v, got %v", tt.WantSkipped, err)
+			}
+		})
+	}
+}
+
+func TestDisabledProgramsEnv(t *testing.T) {
+	defer SetDisabledPrograms()
+	if err := setDisabledProgramsEnv("override[,other"); err == nil {
+		t.Errorf("want error")
+	}
+	if err := setDisabledProgramsEnv("override, other"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	} else if got := DisabledPrograms(); len(got) != 2 || got[0] != "override" || got[1] != "other" {
+		t.Errorf("unexpected patterns: %v", got)
+	}
+}
+
+func TestProgramSwitchGlob(t *testing.T) {
+	tests := []struct {
+		Name         string
+		Pattern      string
+		Element      protoreflect.FullName
+		Id           string
+		WantErr      bool
+		WantDisabled bool
+	}{
+		{
+			Name:         "Id containing /",
+			Pattern:      "testdata/*",
+			Element:      "testdata.TestRpcRequest.ref",
+			Id:           "testdata/Ref",
+			WantDisabled: true,
+		},
+		{
+			Name:         "Element and id containing /",
+			Pattern:      "testdata.*:*/Ref",
+			Element:      "testdata.TestRpcRequest.ref",
+			Id:           "example.com/path/Ref",
+			WantDisabled: true,
+		},
+		{
+			Name:         "Id containing :",
+			Pattern:      "*:urn:deny*",
+			Element:      "testdata.validate.MessageOverride",
+			Id:           "urn:deny:all",
+			WantDisabled: true,
+		},
+		{
+			Name:    "Id containing : without element",
+			Pattern: "urn:deny",
+			Element: "testdata.validate.MessageOverride",
+			Id:      "urn:deny",
+		},
+		{
+			Name:    "Ambiguous element",
+			Pattern: "example.com/Ref:deny",
+			WantErr: true,
+		},
+		{
+			Name:    "Other id",
+			Pattern: "testdata.*:testdata/?ef",
+			Element: "testdata.TestRpcRequest.ref",
+			Id:      "testdata/Refs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			s, err := newProgramSwitch([]string{tt.Pattern})
+			if (err != nil) != tt.WantErr {
+				t.Fatalf("wantErr %v, got %v", tt.WantErr, err)
+			} else if err != nil {
+				return
+			}
+			if got := s.disabled(tt.Element, tt.Id); got != tt.WantDisabled {
+				t.Errorf("wantDisabled %v, got %v", tt.WantDisabled, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package validate
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/validate/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ViolationListener is notified of the failed validations, nested ones being
+// reported once through the validation they are part of
+type ViolationListener func(ctx context.Context, err errors.ValidateError)
+
+// SkipListener is notified of the programs skipped because they are disabled
+// at runtime, with the service, method, message or field they apply to
+type SkipListener func(ctx context.Context, desc protoreflect.Descriptor, program *ValidateProgram)
+
+var (
+	violationListeners = &listenerRegistry[ViolationListener]{}
+	auditListeners     = &listenerRegistry[ViolationListener]{}
+	skipListeners      = &listenerRegistry[SkipListener]{}
+)
+
+type listenerRegistry[L any] struct {
+	mu        sync.RWMutex
+	next      int
+	listeners map[int]L
+}
+
+func (r *listenerRegistry[L]) add(listener L) (remove func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.listeners == nil {
+		r.listeners = map[int]L{}
+	}
+	id := r.next
+	r.next++
+	r.listeners[id] = listener
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.listeners, id)
+	}
+}
+
+func (r *listenerRegistry[L]) each(fn func(listener L)) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, listener := range r.listeners {
+		fn(listener)
+	}
+}
+
+// AddViolationListener registers the listener for the validations of every
+// manager, until the returned function is called
+func AddViolationListener(listener ViolationListener) (remove func()) {
+	return violationListeners.add(listener)
+}
+
+// AddAuditListener registers the listener for the audit programs which are not
+// satisfied, and for every failure when the shadow mode is enabled, until the
+// returned function is called
+func AddAuditListener(listener ViolationListener) (remove func()) {
+	return auditListeners.add(listener)
+}
+
+// AddSkipListener registers the listener for the validations of every
+// manager, until the returned function is called
+func AddSkipListener(listener SkipListener) (remove func()) {
+	return skipListeners.add(listener)
+}
+
+func notifyViolation(ctx context.Context, err error) {
+	vErr, ok := err.(errors.ValidateError)
+	if !ok {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	violationListeners.each(func(listener ViolationListener) {
+		listener(ctx, vErr)
+	})
+}
+
+func notifyAudit(ctx context.Context, err errors.ValidateError) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	auditListeners.each(func(listener ViolationListener) {
+		listener(ctx, err)
+	})
+}
+
+func notifySkip(ctx context.Context, desc protoreflect.Descriptor, program *ValidateProgram) {
+	skipListeners.each(func(listener SkipListener) {
+		listener(ctx, desc, program)
+	})
+}
@@ -0,0 +1,37 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate/errors"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestAddViolationListener(t *testing.T) {
+	desc := validate.File_testdata_validate_message_proto.Messages().ByName("MessageOverride")
+	v, err := newManager(desc.ParentFile()).GetMessageRuleValidater(desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	violations := []errors.ValidateError{}
+	remove := AddViolationListener(func(ctx context.Context, err errors.ValidateError) {
+		violations = append(violations, err)
+	})
+	m := &validate.MessageOverride{}
+	if err := v.ValidateWithMask(context.Background(), m, &fieldmaskpb.FieldMask{Paths: []string{"*"}}); err == nil {
+		t.Fatal("want error")
+	}
+	if err := v.ValidateWithMask(context.Background(), &validate.MessageOverride{Name: "name"}, &fieldmaskpb.FieldMask{Paths: []string{"*"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	remove()
+	v.ValidateWithMask(context.Background(), m, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+	if len(violations) != 1 {
+		t.Fatalf("want 1 violation, got %d", len(violations))
+	}
+	if violations[0].GetProgramId() != "override" || violations[0].GetExpr() != `name != ""` || violations[0].GetMessage() != m {
+		t.Errorf("unexpected violation: %v (%s, %s)", violations[0], violations[0].GetProgramId(), violations[0].GetExpr())
+	}
+}
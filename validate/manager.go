@@ -3,27 +3,88 @@ package validate
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	sync "sync"
 
 	"github.com/google/cel-go/cel"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
 var registry = &managerRegistry{
 	registry: &sync.Map{}, // map[string]map[Manager]bool
 }
 
+// RegisterManager registers a manager built on first use, by the returned
+// function or when the registered managers are used, e.g. by LoadOptions. The
+// generated code registers its managers this way, as the descriptor of a file
+// is only set once the package is initialized.
+func RegisterManager(build func() (*Manager, error)) func() (*Manager, error) {
+	return registry.RegisterLazy(build)
+}
+
 func LoadLibrary(pattern string, lib cel.Library) error {
 	return registry.LoadLibrary(pattern, lib)
 }
 
+func LoadOptions(pattern string, opts ...ManagerOption) error {
+	return registry.LoadOptions(pattern, opts...)
+}
+
+// Managers returns the registered managers, sorted by file path
+func Managers() []*Manager {
+	return registry.Managers()
+}
+
 type managerRegistry struct {
 	registry *sync.Map
+	pending  sync.Map // map[*lazyManager]bool
+}
+
+type lazyManager struct {
+	once  sync.Once
+	build func() (*Manager, error)
+	m     *Manager
+	err   error
+}
+
+func (l *lazyManager) get() (*Manager, error) {
+	l.once.Do(func() {
+		l.m, l.err = l.build()
+	})
+	return l.m, l.err
+}
+
+func (r *managerRegistry) RegisterLazy(build func() (*Manager, error)) func() (*Manager, error) {
+	l := &lazyManager{build: build}
+	r.pending.Store(l, true)
+	return func() (*Manager, error) {
+		m, err := l.get()
+		r.pending.Delete(l)
+		return m, err
+	}
+}
+
+// build builds the pending managers, which register themselves
+func (r *managerRegistry) build() error {
+	var err error
+	r.pending.Range(func(key, value any) bool {
+		l := key.(*lazyManager)
+		if _, e := l.get(); e != nil && err == nil {
+			err = e
+		}
+		r.pending.Delete(l)
+		return true
+	})
+	return err
 }
 
 func (r *managerRegistry) LoadLibrary(pattern string, lib cel.Library) error {
 	if lib != nil {
+		if err := r.build(); err != nil {
+			return err
+		}
 		var err error
 		r.registry.Range(func(key, value any) bool {
 			var registryErr error
@@ -48,6 +109,39 @@ func (r *managerRegistry) LoadLibrary(pattern string, lib cel.Library) error {
 	return nil
 }
 
+func (r *managerRegistry) LoadOptions(pattern string, opts ...ManagerOption) error {
+	if err := r.build(); err != nil {
+		return err
+	}
+	var err error
+	r.registry.Range(func(key, value any) bool {
+		if ok, _ := filepath.Match(pattern, key.(string)); ok {
+			value.(*sync.Map).Range(func(key, value any) bool {
+				err = key.(*Manager).LoadOptions(opts...)
+				return err == nil
+			})
+		}
+		return err == nil
+	})
+	return err
+}
+
+func (r *managerRegistry) Managers() []*Manager {
+	r.build()
+	managers := []*Manager{}
+	r.registry.Range(func(key, value any) bool {
+		value.(*sync.Map).Range(func(key, value any) bool {
+			managers = append(managers, key.(*Manager))
+			return true
+		})
+		return true
+	})
+	sort.SliceStable(managers, func(i, j int) bool {
+		return managers[i].file.Path() < managers[j].file.Path()
+	})
+	return managers
+}
+
 func (r *managerRegistry) Register(m *Manager) error {
 	if m == nil {
 		return fmt.Errorf("nil manager")
@@ -70,7 +164,66 @@ func (opt managerOption) apply(b *builder) { opt(b) }
 
 func WithFallbackOverloads() ManagerOption {
 	return managerOption(func(b *builder) {
-		b.ob = &fallbackOverloadBuilder{b}
+		b.ob = &fallbackOverloadBuilder{Builder: b}
+	})
+}
+
+// WithCostLimit bounds the cumulated runtime cost of the programs evaluated
+// during a single validation
+func WithCostLimit(limit uint64) ManagerOption {
+	return managerOption(func(b *builder) {
+		b.costLimit = limit
+	})
+}
+
+// WithProgramCostLimit bounds the runtime cost of every program, unless a
+// cost_limit is defined in the rule options
+func WithProgramCostLimit(limit uint64) ManagerOption {
+	return managerOption(func(b *builder) {
+		b.programCostLimit = limit
+	})
+}
+
+// WithProgramErrorHandler reports the programs which cannot be built to the
+// handler, with the descriptor they apply to, instead of failing the build
+func WithProgramErrorHandler(handler func(desc protoreflect.Descriptor, program *Rule_Program, err error)) ManagerOption {
+	return managerOption(func(b *builder) {
+		b.onProgramError = handler
+	})
+}
+
+// WithFiles sets the files used for resolving the names referenced by the
+// configuration, protoregistry.GlobalFiles by default
+func WithFiles(files *protoregistry.Files) ManagerOption {
+	return managerOption(func(b *builder) {
+		b.files = files
+	})
+}
+
+// WithLenientConfiguration checks the configuration when building the
+// validaters, giving its unresolved references to warn, if not nil, instead
+// of failing
+func WithLenientConfiguration(warn func(err error)) ManagerOption {
+	return managerOption(func(b *builder) {
+		b.checked, b.lenient = true, true
+		b.warn = warn
+	})
+}
+
+// WithConfigurationWarnings checks the configuration when building the
+// validaters, giving its unresolved references outside of the package of the
+// file to warn
+func WithConfigurationWarnings(warn func(err error)) ManagerOption {
+	return managerOption(func(b *builder) {
+		b.checked = true
+		b.warn = warn
+	})
+}
+
+// WithShadowMode enables the shadow mode, see Manager.SetShadowMode
+func WithShadowMode() ManagerOption {
+	return managerOption(func(b *builder) {
+		b.shadow.Store(true)
 	})
 }
 
@@ -93,6 +246,21 @@ func NewManager(file protoreflect.FileDescriptor, opts ...ManagerOption) (*Manag
 	if file == nil {
 		return nil, fmt.Errorf("nil file descriptor")
 	}
+	m := newManager(file, opts...)
+	return m, registry.Register(m)
+}
+
+// NewUnregisteredManager returns a manager which is not registered, and thus
+// ignored by LoadLibrary, LoadOptions, Managers and Reload, e.g. for tools
+// building the validaters of the files they inspect
+func NewUnregisteredManager(file protoreflect.FileDescriptor, opts ...ManagerOption) (*Manager, error) {
+	if file == nil {
+		return nil, fmt.Errorf("nil file descriptor")
+	}
+	return newManager(file, opts...), nil
+}
+
+func newManager(file protoreflect.FileDescriptor, opts ...ManagerOption) *Manager {
 	m := &Manager{
 		file:  file,
 		onces: &sync.Map{},
@@ -109,12 +277,15 @@ func NewManager(file protoreflect.FileDescriptor, opts ...ManagerOption) (*Manag
 	for _, opt := range opts {
 		opt.apply(m.b)
 	}
-	return m, registry.Register(m)
+	// nested messages without generated code share the cached validaters
+	m.b.nested = m.GetMessageRuleValidater
+	return m
 }
 
 type Manager struct {
 	file              protoreflect.FileDescriptor
 	onces             *sync.Map
+	mu                sync.RWMutex
 	serviceValidaters map[string]struct {
 		rv  ServiceRuleValidater
 		err error
@@ -125,10 +296,25 @@ type Manager struct {
 	}
 
 	b *builder
+
+	reloadMu sync.Mutex
+	reloaded bool
+	base     *Configuration
+}
+
+func (m *Manager) File() protoreflect.FileDescriptor {
+	return m.file
+}
+
+// SetShadowMode switches every program of the manager to the audit mode, or
+// back: failures are then given to the audit listeners instead of failing the
+// validations
+func (m *Manager) SetShadowMode(enabled bool) {
+	m.builder().shadow.Store(enabled)
 }
 
 func (m *Manager) LoadLibrary(lib cel.Library) error {
-	if len(m.serviceValidaters) > 0 || len(m.messageValidaters) > 0 {
+	if m.used() {
 		return fmt.Errorf("cannot load library: manager already used")
 	} else if lib == nil {
 		return nil
@@ -141,7 +327,29 @@ func (m *Manager) LoadLibrary(lib cel.Library) error {
 	return nil
 }
 
+func (m *Manager) LoadOptions(opts ...ManagerOption) error {
+	if m.used() {
+		return fmt.Errorf("cannot load options: manager already used")
+	}
+	for _, opt := range opts {
+		opt.apply(m.b)
+	}
+	return nil
+}
+
+func (m *Manager) used() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.serviceValidaters) > 0 || len(m.messageValidaters) > 0
+}
+
+// BuildValidaters builds the validaters of the services and messages of the
+// file, after checking the configuration if asked to with
+// WithConfigurationWarnings or WithLenientConfiguration
 func (m *Manager) BuildValidaters() error {
+	if err := m.checkConfiguration(); err != nil {
+		return err
+	}
 	for i := 0; i < m.file.Services().Len(); i++ {
 		if _, err := m.GetServiceRuleValidater(m.file.Services().Get(i)); err != nil {
 			return err
@@ -157,26 +365,40 @@ func (m *Manager) BuildValidaters() error {
 
 func (m *Manager) GetServiceRuleValidater(desc protoreflect.ServiceDescriptor) (ServiceRuleValidater, error) {
 	key := string(desc.FullName())
-	once, _ := m.onces.LoadOrStore(key, &sync.Once{})
+	m.mu.RLock()
+	b, onces, validaters := m.b, m.onces, m.serviceValidaters
+	m.mu.RUnlock()
+	once, _ := onces.LoadOrStore(key, &sync.Once{})
 	once.(*sync.Once).Do(func() {
-		rv, err := m.b.BuildServiceRuleValidater(desc)
-		m.serviceValidaters[key] = struct {
+		rv, err := b.BuildServiceRuleValidater(desc)
+		m.mu.Lock()
+		validaters[key] = struct {
 			rv  ServiceRuleValidater
 			err error
 		}{rv: rv, err: err}
+		m.mu.Unlock()
 	})
-	return m.serviceValidaters[key].rv, m.serviceValidaters[key].err
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return validaters[key].rv, validaters[key].err
 }
 
 func (m *Manager) GetMessageRuleValidater(desc protoreflect.MessageDescriptor) (MessageRuleValidater, error) {
 	key := string(desc.FullName())
-	once, _ := m.onces.LoadOrStore(key, &sync.Once{})
+	m.mu.RLock()
+	b, onces, validaters := m.b, m.onces, m.messageValidaters
+	m.mu.RUnlock()
+	once, _ := onces.LoadOrStore(key, &sync.Once{})
 	once.(*sync.Once).Do(func() {
-		rv, err := m.b.BuildMessageRuleValidater(desc)
-		m.messageValidaters[key] = struct {
+		rv, err := b.BuildMessageRuleValidater(desc)
+		m.mu.Lock()
+		validaters[key] = struct {
 			rv  MessageRuleValidater
 			err error
 		}{rv: rv, err: err}
+		m.mu.Unlock()
 	})
-	return m.messageValidaters[key].rv, m.serviceValidaters[key].err
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return validaters[key].rv, validaters[key].err
 }
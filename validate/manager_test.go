@@ -86,6 +86,84 @@ func TestManagerRegistry(t *testing.T) {
 	}
 }
 
+func TestManagerRegistryLazy(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Build   func() (*Manager, error)
+		Use     func(r *managerRegistry) error
+		WantErr bool
+	}{
+		{
+			Name: "Accessor",
+			Build: func() (*Manager, error) {
+				return NewManager(validate.File_testdata_validate_test_proto)
+			},
+		},
+		{
+			Name: "LoadOptions",
+			Build: func() (*Manager, error) {
+				return NewManager(validate.File_testdata_validate_test_proto)
+			},
+			Use: func(r *managerRegistry) error {
+				return r.LoadOptions("*")
+			},
+		},
+		{
+			Name: "Managers",
+			Build: func() (*Manager, error) {
+				return NewManager(validate.File_testdata_validate_test_proto)
+			},
+			Use: func(r *managerRegistry) error {
+				if len(r.Managers()) != 1 {
+					return fmt.Errorf("manager not registered")
+				}
+				return nil
+			},
+		},
+		{
+			Name: "Build error",
+			Build: func() (*Manager, error) {
+				return nil, fmt.Errorf("build error")
+			},
+			Use: func(r *managerRegistry) error {
+				return r.LoadOptions("*")
+			},
+			WantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			r := &managerRegistry{registry: &sync.Map{}}
+			registry = r
+			defer func() { registry = &managerRegistry{registry: &sync.Map{}} }()
+			built := 0
+			get := r.RegisterLazy(func() (*Manager, error) {
+				built++
+				return tt.Build()
+			})
+			if built != 0 {
+				t.Fatalf("manager built on registration")
+			}
+			var err error
+			if tt.Use != nil {
+				err = tt.Use(r)
+			} else {
+				_, err = get()
+			}
+			if (tt.WantErr && err == nil) || (!tt.WantErr && err != nil) {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			}
+			m, err := get()
+			if (tt.WantErr && err == nil) || (!tt.WantErr && (err != nil || m == nil)) {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			}
+			if built != 1 {
+				t.Errorf("manager built %d times", built)
+			}
+		})
+	}
+}
+
 func TestManager(t *testing.T) {
 	tests := []struct {
 		Name               string
@@ -105,6 +183,11 @@ func TestManager(t *testing.T) {
 			File:         validate.File_testdata_validate_manager_proto,
 			WantBuildErr: true,
 		},
+		{
+			Name:         "Message build error",
+			File:         validate.File_testdata_validate_field_proto,
+			WantBuildErr: true,
+		},
 		{
 			Name: "OK (const declared in options)",
 			File: validate.File_testdata_validate_manager_proto,
@@ -158,3 +241,50 @@ func TestManager(t *testing.T) {
 		})
 	}
 }
+
+func TestManagerLoadOptions(t *testing.T) {
+	registry = &managerRegistry{registry: &sync.Map{}}
+	m, err := NewManager(validate.File_testdata_validate_manager_proto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = LoadOptions("testdata.*", WithConfiguration(&Configuration{
+		Rule: &FileRule{
+			Options: &Options{
+				Globals: &Options_Globals{
+					Constants: map[string]string{"name_const": "name"},
+				},
+			},
+		},
+	})); err != nil {
+		t.Errorf("load options error: %v", err)
+	}
+	if err = m.BuildValidaters(); err != nil {
+		t.Errorf("build error: %v", err)
+	}
+	if err = LoadOptions("*", WithFallbackOverloads()); err == nil {
+		t.Errorf("want error on used manager")
+	}
+}
+
+func TestManagerProgramErrorHandler(t *testing.T) {
+	failed := map[protoreflect.FullName]string{}
+	m := newManager(validate.File_testdata_validate_manager_proto, WithProgramErrorHandler(func(desc protoreflect.Descriptor, program *Rule_Program, err error) {
+		failed[desc.FullName()] = program.Expr
+	}))
+	if err := m.BuildValidaters(); err != nil {
+		t.Errorf("build error: %v", err)
+	}
+	want := map[protoreflect.FullName]string{
+		"testdata.validate.Manager.ManagerRpc": "request.name == name_const",
+		"testdata.validate.ManagerRpcRequest":  "name == name_const",
+	}
+	if len(failed) != len(want) {
+		t.Fatalf("want %v, got %v", want, failed)
+	}
+	for k, v := range want {
+		if failed[k] != v {
+			t.Errorf("want %q for %s, got %q", v, k, failed[k])
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package validate
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// mergeRule merges src into dst like proto.Merge, except for the programs: a
+// program with an id replaces the inherited program with the same id. The ids
+// must be unique within src.
+func mergeRule(dst, src *Rule) error {
+	if src == nil {
+		return nil
+	}
+	ids := map[string]bool{}
+	for _, program := range src.Programs {
+		if program.Id == "" {
+			continue
+		} else if ids[program.Id] {
+			return fmt.Errorf("duplicate program id %q", program.Id)
+		}
+		ids[program.Id] = true
+	}
+	if src.Options != nil {
+		if dst.Options == nil {
+			dst.Options = &Options{}
+		}
+		proto.Merge(dst.Options, src.Options)
+	}
+	for _, program := range src.Programs {
+		program = proto.Clone(program).(*Rule_Program)
+		replaced := false
+		if program.Id != "" {
+			for i, inherited := range dst.Programs {
+				if inherited.Id == program.Id {
+					dst.Programs[i], replaced = program, true
+					break
+				}
+			}
+		}
+		if !replaced {
+			dst.Programs = append(dst.Programs, program)
+		}
+	}
+	return nil
+}
+
+// mergeConfiguration merges src into dst like proto.Merge, except for the
+// rules, which are merged key by key
+func mergeConfiguration(dst, src *Configuration) error {
+	if src == nil {
+		return nil
+	}
+	rule := src.Rule
+	src = proto.Clone(src).(*Configuration)
+	src.Rule = nil
+	proto.Merge(dst, src)
+	if rule != nil {
+		if dst.Rule == nil {
+			dst.Rule = &FileRule{}
+		}
+		return mergeFileRule(dst.Rule, rule)
+	}
+	return nil
+}
+
+func mergeFileRule(dst, src *FileRule) error {
+	if src.Options != nil {
+		if dst.Options == nil {
+			dst.Options = &Options{}
+		}
+		proto.Merge(dst.Options, src.Options)
+	}
+	for name, sr := range src.ServiceRules {
+		if dst.ServiceRules == nil {
+			dst.ServiceRules = map[string]*ServiceRule{}
+		}
+		if dst.ServiceRules[name] == nil {
+			dst.ServiceRules[name] = &ServiceRule{}
+		}
+		if err := mergeServiceRule(dst.ServiceRules[name], sr); err != nil {
+			return fmt.Errorf("service %s: %w", name, err)
+		}
+	}
+	for name, mr := range src.MessageRules {
+		if dst.MessageRules == nil {
+			dst.MessageRules = map[string]*MessageRule{}
+		}
+		if dst.MessageRules[name] == nil {
+			dst.MessageRules[name] = &MessageRule{}
+		}
+		if err := mergeMessageRule(dst.MessageRules[name], mr); err != nil {
+			return fmt.Errorf("message %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func mergeServiceRule(dst, src *ServiceRule) error {
+	if src == nil {
+		return nil
+	}
+	rule, methodRules := src.Rule, src.MethodRules
+	src = proto.Clone(src).(*ServiceRule)
+	src.Rule, src.MethodRules = nil, nil
+	proto.Merge(dst, src)
+	if rule != nil {
+		if dst.Rule == nil {
+			dst.Rule = &Rule{}
+		}
+		if err := mergeRule(dst.Rule, rule); err != nil {
+			return err
+		}
+	}
+	for name, mr := range methodRules {
+		if dst.MethodRules == nil {
+			dst.MethodRules = map[string]*MethodRule{}
+		}
+		if dst.MethodRules[name] == nil {
+			dst.MethodRules[name] = &MethodRule{}
+		}
+		if mr.GetRule() != nil {
+			if dst.MethodRules[name].Rule == nil {
+				dst.MethodRules[name].Rule = &Rule{}
+			}
+			if err := mergeRule(dst.MethodRules[name].Rule, mr.Rule); err != nil {
+				return fmt.Errorf("method %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func mergeMessageRule(dst, src *MessageRule) error {
+	if src == nil {
+		return nil
+	}
+	rule, fieldRules := src.Rule, src.FieldRules
+	src = proto.Clone(src).(*MessageRule)
+	src.Rule, src.FieldRules = nil, nil
+	proto.Merge(dst, src)
+	if rule != nil {
+		if dst.Rule == nil {
+			dst.Rule = &Rule{}
+		}
+		if err := mergeRule(dst.Rule, rule); err != nil {
+			return err
+		}
+	}
+	for name, fr := range fieldRules {
+		if dst.FieldRules == nil {
+			dst.FieldRules = map[string]*FieldRule{}
+		}
+		if dst.FieldRules[name] == nil {
+			dst.FieldRules[name] = &FieldRule{}
+		}
+		if err := mergeFieldRule(dst.FieldRules[name], fr); err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func mergeFieldRule(dst, src *FieldRule) error {
+	if src == nil {
+		return nil
+	}
+	rule := src.Rule
+	src = proto.Clone(src).(*FieldRule)
+	src.Rule = nil
+	proto.Merge(dst, src)
+	if rule != nil {
+		if dst.Rule == nil {
+			dst.Rule = &Rule{}
+		}
+		return mergeRule(dst.Rule, rule)
+	}
+	return nil
+}
+
+// enabledPrograms removes the disabled programs, once every layer is merged
+func enabledPrograms(rule *Rule) {
+	programs := []*Rule_Program{}
+	for _, program := range rule.Programs {
+		if !program.Disabled {
+			programs = append(programs, program)
+		}
+	}
+	rule.Programs = programs
+}
+
+// sources records the layer each merged program comes from
+type sources map[*Rule_Program]Source
+
+// mergeRule merges src into dst, recording the source of the added programs
+func (s sources) mergeRule(dst, src *Rule, source Source) error {
+	if err := mergeRule(dst, src); err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	s.record(dst, source)
+	return nil
+}
+
+func (s sources) mergeServiceRule(dst, src *ServiceRule, source Source) error {
+	if err := mergeServiceRule(dst, src); err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	s.record(dst.Rule, source)
+	return nil
+}
+
+func (s sources) mergeMessageRule(dst, src *MessageRule, source Source) error {
+	if err := mergeMessageRule(dst, src); err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	s.record(dst.Rule, source)
+	return nil
+}
+
+// add appends the program generated from the descriptor to the rule, unless
+// a program of the merged layers has its id: generated programs are inherited
+// by every layer, which can replace or disable them
+func (s sources) add(rule *Rule, program *Rule_Program, source Source) {
+	for _, other := range rule.Programs {
+		if program.Id != "" && other.Id == program.Id {
+			return
+		}
+	}
+	rule.Programs = append(rule.Programs, program)
+	s[program] = source
+}
+
+func (s sources) record(rule *Rule, source Source) {
+	for _, program := range rule.GetPrograms() {
+		if _, ok := s[program]; !ok {
+			s[program] = source
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package validate
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMergeRule(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Dst     *Rule
+		Src     *Rule
+		Want    *Rule
+		WantErr bool
+	}{
+		{
+			Name: "Nil source",
+			Dst:  &Rule{Programs: []*Rule_Program{{Id: "a", Expr: "true"}}},
+			Want: &Rule{Programs: []*Rule_Program{{Id: "a", Expr: "true"}}},
+		},
+		{
+			Name: "Append",
+			Dst:  &Rule{Programs: []*Rule_Program{{Id: "a", Expr: "true"}, {Expr: "true"}}},
+			Src:  &Rule{Programs: []*Rule_Program{{Id: "b", Expr: "false"}, {Expr: "true"}}},
+			Want: &Rule{Programs: []*Rule_Program{{Id: "a", Expr: "true"}, {Expr: "true"}, {Id: "b", Expr: "false"}, {Expr: "true"}}},
+		},
+		{
+			Name: "Replace",
+			Dst:  &Rule{Programs: []*Rule_Program{{Id: "a", Expr: "true"}, {Id: "b", Expr: "true"}}},
+			Src:  &Rule{Programs: []*Rule_Program{{Id: "a", Expr: "false"}}},
+			Want: &Rule{Programs: []*Rule_Program{{Id: "a", Expr: "false"}, {Id: "b", Expr: "true"}}},
+		},
+		{
+			Name: "Disable",
+			Dst:  &Rule{Programs: []*Rule_Program{{Id: "a", Expr: "true"}}},
+			Src:  &Rule{Programs: []*Rule_Program{{Id: "a", Disabled: true}}},
+			Want: &Rule{Programs: []*Rule_Program{{Id: "a", Disabled: true}}},
+		},
+		{
+			Name:    "Duplicate id",
+			Dst:     &Rule{Programs: []*Rule_Program{{Id: "a", Expr: "true"}}},
+			Src:     &Rule{Programs: []*Rule_Program{{Id: "a", Expr: "false"}, {Id: "a", Disabled: true}}},
+			WantErr: true,
+		},
+		{
+			Name: "Options",
+			Dst:  &Rule{Options: &Options{CostLimit: 1}},
+			Src:  &Rule{Options: &Options{StdlibOverridingEnabled: true}},
+			Want: &Rule{Options: &Options{CostLimit: 1, StdlibOverridingEnabled: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			if err := mergeRule(tt.Dst, tt.Src); err != nil != tt.WantErr {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			} else if err == nil && !proto.Equal(tt.Dst, tt.Want) {
+				t.Errorf("want %v, got %v", tt.Want, tt.Dst)
+			}
+		})
+	}
+}
+
+func TestMergeServiceRule(t *testing.T) {
+	dst := &ServiceRule{
+		Rule:        &Rule{Programs: []*Rule_Program{{Id: "a", Expr: "true"}}},
+		MethodRules: map[string]*MethodRule{"Rpc": {}},
+	}
+	src := &ServiceRule{
+		Options: &Options{CostLimit: 1},
+		Rule:    &Rule{Programs: []*Rule_Program{{Id: "a", Expr: "false"}}},
+	}
+	if err := mergeServiceRule(dst, src); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &ServiceRule{
+		Options:     &Options{CostLimit: 1},
+		Rule:        &Rule{Programs: []*Rule_Program{{Id: "a", Expr: "false"}}},
+		MethodRules: map[string]*MethodRule{"Rpc": {}},
+	}
+	if !proto.Equal(dst, want) {
+		t.Errorf("want %v, got %v", want, dst)
+	} else if src.Rule == nil {
+		t.Errorf("source modified")
+	}
+}
+
+func TestEnabledPrograms(t *testing.T) {
+	rule := &Rule{Programs: []*Rule_Program{{Id: "a", Disabled: true}, {Id: "b", Expr: "true"}}}
+	enabledPrograms(rule)
+	if want := (&Rule{Programs: []*Rule_Program{{Id: "b", Expr: "true"}}}); !proto.Equal(rule, want) {
+		t.Errorf("want %v, got %v", want, rule)
+	}
+}
@@ -0,0 +1,115 @@
+package validate
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Outcome is the result of a program evaluation, or of a validation
+type Outcome int
+
+const (
+	OutcomePassed Outcome = iota
+	OutcomeFailed
+	OutcomeSkipped
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeFailed:
+		return "failed"
+	case OutcomeSkipped:
+		return "skipped"
+	default:
+		return "passed"
+	}
+}
+
+// ProgramEvent describes the evaluation of a program, on behalf of the
+// service, method, message or field it applies to
+type ProgramEvent struct {
+	Descriptor protoreflect.Descriptor
+	ProgramId  string
+	Expr       string
+	Start      time.Time
+	Duration   time.Duration
+	Outcome    Outcome
+	// Err is set when the outcome is OutcomeFailed, even if the program only
+	// audits
+	Err error
+}
+
+// ValidationEvent describes a top-level validation of a method call or a
+// message, nested validations being part of it
+type ValidationEvent struct {
+	Descriptor protoreflect.Descriptor
+	Start      time.Time
+	Duration   time.Duration
+	Outcome    Outcome
+	Err        error
+}
+
+// Observer is notified of the program evaluations and validations, e.g. for
+// metrics and tracing. Its methods are called synchronously and must be safe
+// for concurrent use.
+type Observer interface {
+	ObserveProgram(ctx context.Context, event *ProgramEvent)
+	ObserveValidation(ctx context.Context, event *ValidationEvent)
+}
+
+// WithObserver sets the observer of the validations of the manager
+func WithObserver(observer Observer) ManagerOption {
+	return managerOption(func(b *builder) {
+		b.observer = observer
+	})
+}
+
+type observerKey struct{}
+
+// ContextWithObserver returns a context whose validations are also notified
+// to the observer, in addition to the observer of the manager
+func ContextWithObserver(ctx context.Context, observer Observer) context.Context {
+	return context.WithValue(ctx, observerKey{}, observer)
+}
+
+// observers calls fn with the observers of the evaluation and of the context
+func (e *evaluation) observers(ctx context.Context, fn func(o Observer)) {
+	if e.observer != nil {
+		fn(e.observer)
+	}
+	if ctx != nil {
+		if o, ok := ctx.Value(observerKey{}).(Observer); ok && o != nil {
+			fn(o)
+		}
+	}
+}
+
+func (e *evaluation) observeProgram(ctx context.Context, desc protoreflect.Descriptor, pgr *ValidateProgram, start time.Time, outcome Outcome, err error) {
+	e.coverage.record(desc, pgr, outcome)
+	coverages.each(func(c *Coverage) {
+		c.record(desc, pgr, outcome)
+	})
+	e.observers(ctx, func(o Observer) {
+		o.ObserveProgram(ctx, &ProgramEvent{
+			Descriptor: desc,
+			ProgramId:  pgr.Id,
+			Expr:       pgr.Expr,
+			Start:      start,
+			Duration:   time.Since(start),
+			Outcome:    outcome,
+			Err:        err,
+		})
+	})
+}
+
+func (e *evaluation) observeValidation(ctx context.Context, desc protoreflect.Descriptor, start time.Time, err error) {
+	e.observers(ctx, func(o Observer) {
+		event := &ValidationEvent{Descriptor: desc, Start: start, Duration: time.Since(start), Err: err}
+		if err != nil {
+			event.Outcome = OutcomeFailed
+		}
+		o.ObserveValidation(ctx, event)
+	})
+}
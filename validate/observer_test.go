@@ -0,0 +1,90 @@
+package validate
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+type recordingObserver struct {
+	mu          sync.Mutex
+	programs    []string
+	validations []string
+}
+
+func (o *recordingObserver) ObserveProgram(ctx context.Context, event *ProgramEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.programs = append(o.programs, event.ProgramId+":"+event.Outcome.String())
+}
+
+func (o *recordingObserver) ObserveValidation(ctx context.Context, event *ValidationEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.validations = append(o.validations, string(event.Descriptor.FullName())+":"+event.Outcome.String())
+}
+
+func TestObserver(t *testing.T) {
+	config := &Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+		"testdata.validate.MessageOverride": {Rule: &Rule{Programs: []*Rule_Program{
+			{Id: "audit", Expr: `name.startsWith("a")`, Mode: Rule_Program_AUDIT},
+			{Id: "skipped", Expr: "false"},
+		}}},
+	}}, DisabledPrograms: []string{"skipped"}}
+	tests := []struct {
+		Name            string
+		Context         bool
+		Message         proto.Message
+		WantPrograms    []string
+		WantValidations []string
+	}{
+		{
+			Name:            "Passed",
+			Message:         &validate.MessageOverride{Name: "abc"},
+			WantPrograms:    []string{"audit:passed", "skipped:skipped", "override:passed"},
+			WantValidations: []string{"testdata.validate.MessageOverride:passed"},
+		},
+		{
+			Name:            "Failed",
+			Message:         &validate.MessageOverride{},
+			WantPrograms:    []string{"audit:failed", "skipped:skipped", "override:failed"},
+			WantValidations: []string{"testdata.validate.MessageOverride:failed"},
+		},
+		{
+			Name:            "Context",
+			Context:         true,
+			Message:         &validate.MessageOverride{Name: "b"},
+			WantPrograms:    []string{"audit:failed", "skipped:skipped", "override:passed"},
+			WantValidations: []string{"testdata.validate.MessageOverride:passed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			o := &recordingObserver{}
+			ctx := context.Background()
+			opts := []ManagerOption{WithConfiguration(config)}
+			if tt.Context {
+				ctx = ContextWithObserver(ctx, o)
+			} else {
+				opts = append(opts, WithObserver(o))
+			}
+			desc := tt.Message.ProtoReflect().Descriptor()
+			v, err := newManager(desc.ParentFile(), opts...).GetMessageRuleValidater(desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v.ValidateWithMask(ctx, tt.Message, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+			if !reflect.DeepEqual(o.programs, tt.WantPrograms) {
+				t.Errorf("want programs %v, got %v", tt.WantPrograms, o.programs)
+			}
+			if !reflect.DeepEqual(o.validations, tt.WantValidations) {
+				t.Errorf("want validations %v, got %v", tt.WantValidations, o.validations)
+			}
+		})
+	}
+}
@@ -1,25 +1,28 @@
 package validate
 
 import (
-	"context"
 	"fmt"
+	"sync"
 
 	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common"
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/parser"
+	v1alpha1 "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
-func buildOverloads(desc protoreflect.MessageDescriptor, validateUnary func(ref.Val) ref.Val, validateWithMaskBinary func(ref.Val, ref.Val) ref.Val) []cel.EnvOption {
+func buildOverloads(desc protoreflect.MessageDescriptor, validateBinary func(ref.Val, ref.Val) ref.Val, validateWithMaskFunction func(...ref.Val) ref.Val) []cel.EnvOption {
 	res := []cel.EnvOption{}
 	if opts := buildFunctionOpts(desc, "validate", func(name, t string) cel.FunctionOpt {
 		return cel.MemberOverload(
 			fmt.Sprintf("%s_%s", t, name),
-			[]*cel.Type{cel.ObjectType(t)},
+			[]*cel.Type{cel.ObjectType(t), contextType},
 			cel.BoolType,
-			cel.UnaryBinding(validateUnary),
+			cel.BinaryBinding(validateBinary),
 		)
 	}); len(opts) > 0 {
 		res = append(res, cel.Function("validate", opts...))
@@ -27,16 +30,33 @@ func buildOverloads(desc protoreflect.MessageDescriptor, validateUnary func(ref.
 	if opts := buildFunctionOpts(desc, "validateWithMask", func(name, t string) cel.FunctionOpt {
 		return cel.MemberOverload(
 			fmt.Sprintf("%s_%s", t, name),
-			[]*cel.Type{cel.ObjectType(t), cel.ObjectType(string((&fieldmaskpb.FieldMask{}).ProtoReflect().Descriptor().FullName()))},
+			[]*cel.Type{cel.ObjectType(t), cel.ObjectType(string((&fieldmaskpb.FieldMask{}).ProtoReflect().Descriptor().FullName())), contextType},
 			cel.BoolType,
-			cel.BinaryBinding(validateWithMaskBinary),
+			cel.FunctionBinding(validateWithMaskFunction),
 		)
 	}); len(opts) > 0 {
 		res = append(res, cel.Function("validateWithMask", opts...))
 	}
+	if len(res) > 0 {
+		res = append(res,
+			cel.Variable(contextVariable, contextType),
+			cel.Macros(
+				parser.NewReceiverMacro("validate", 0, contextMacroExpander("validate")),
+				parser.NewReceiverMacro("validateWithMask", 1, contextMacroExpander("validateWithMask")),
+			),
+		)
+	}
 	return res
 }
 
+// contextMacroExpander appends the validation context to the arguments of the
+// function, so that nested validations are called with the caller's context
+func contextMacroExpander(function string) parser.MacroExpander {
+	return func(eh parser.ExprHelper, target *v1alpha1.Expr, args []*v1alpha1.Expr) (*v1alpha1.Expr, *common.Error) {
+		return eh.ReceiverCall(function, target, append(args, eh.Ident(contextVariable))...), nil
+	}
+}
+
 func buildFunctionOpts(desc protoreflect.MessageDescriptor, name string, optBuilder func(name, t string) cel.FunctionOpt, m ...map[string]bool) []cel.FunctionOpt {
 	if len(m) == 0 {
 		m = append(m, map[string]bool{})
@@ -75,10 +95,10 @@ func (b *defaultOverloadBuilder) buildOverloads(desc protoreflect.MessageDescrip
 	return buildOverloads(desc, b.validate, b.validateWithMask)
 }
 
-func (b *defaultOverloadBuilder) validate(value ref.Val) ref.Val {
+func (b *defaultOverloadBuilder) validate(value, ctx ref.Val) ref.Val {
 	var err error
 	if v, ok := value.Value().(Validater); ok {
-		err = v.Validate(context.TODO())
+		err = v.Validate(contextFromVal(ctx))
 	} else {
 		return types.Bool(false)
 	}
@@ -91,11 +111,11 @@ func (b *defaultOverloadBuilder) validate(value ref.Val) ref.Val {
 	}
 }
 
-func (b *defaultOverloadBuilder) validateWithMask(lhs, rhs ref.Val) ref.Val {
+func (b *defaultOverloadBuilder) validateWithMask(args ...ref.Val) ref.Val {
 	var err error
-	fm := rhs.Value().(*fieldmaskpb.FieldMask)
-	if v, ok := lhs.Value().(Validater); ok {
-		err = v.ValidateWithMask(context.TODO(), fm)
+	fm := args[1].Value().(*fieldmaskpb.FieldMask)
+	if v, ok := args[0].Value().(Validater); ok {
+		err = v.ValidateWithMask(contextFromVal(args[2]), fm)
 	} else {
 		return types.Bool(false)
 	}
@@ -110,21 +130,41 @@ func (b *defaultOverloadBuilder) validateWithMask(lhs, rhs ref.Val) ref.Val {
 
 type fallbackOverloadBuilder struct {
 	Builder *builder
+	// validaters caches the validaters of the nested messages, when the
+	// builder does not resolve them itself
+	validaters sync.Map
+}
+
+// messageRuleValidater returns the validater of a nested message, from the
+// builder if it resolves them, built once otherwise
+func (b *fallbackOverloadBuilder) messageRuleValidater(desc protoreflect.MessageDescriptor) (MessageRuleValidater, error) {
+	if b.Builder.nested != nil {
+		return b.Builder.nested(desc)
+	}
+	if v, ok := b.validaters.Load(desc.FullName()); ok {
+		return v.(MessageRuleValidater), nil
+	}
+	v, err := b.Builder.BuildMessageRuleValidater(desc)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := b.validaters.LoadOrStore(desc.FullName(), v)
+	return actual.(MessageRuleValidater), nil
 }
 
 func (b *fallbackOverloadBuilder) buildOverloads(desc protoreflect.MessageDescriptor) []cel.EnvOption {
 	return buildOverloads(desc, b.validate, b.validateWithMask)
 }
 
-func (b *fallbackOverloadBuilder) validate(value ref.Val) ref.Val {
+func (b *fallbackOverloadBuilder) validate(value, ctx ref.Val) ref.Val {
 	msg, ok := value.Value().(proto.Message)
 	if ok {
 		desc := msg.ProtoReflect().Descriptor()
-		messageValidater, err := b.Builder.BuildMessageRuleValidater(desc)
+		messageValidater, err := b.messageRuleValidater(desc)
 		if err != nil {
 			return types.NewErr(err.Error())
 		}
-		if err = messageValidater.ValidateWithMask(context.TODO(), msg, &fieldmaskpb.FieldMask{Paths: []string{"*"}}); err != nil {
+		if err = messageValidater.ValidateWithMask(contextFromVal(ctx), msg, &fieldmaskpb.FieldMask{Paths: []string{"*"}}); err != nil {
 			if vErr, ok := err.(ref.Val); ok {
 				return vErr
 			}
@@ -135,16 +175,16 @@ func (b *fallbackOverloadBuilder) validate(value ref.Val) ref.Val {
 	return types.Bool(false)
 }
 
-func (b *fallbackOverloadBuilder) validateWithMask(lhs, rhs ref.Val) ref.Val {
-	fm := rhs.Value().(*fieldmaskpb.FieldMask)
-	msg, ok := lhs.Value().(proto.Message)
+func (b *fallbackOverloadBuilder) validateWithMask(args ...ref.Val) ref.Val {
+	fm := args[1].Value().(*fieldmaskpb.FieldMask)
+	msg, ok := args[0].Value().(proto.Message)
 	if ok {
 		desc := msg.ProtoReflect().Descriptor()
-		messageValidater, err := b.Builder.BuildMessageRuleValidater(desc)
+		messageValidater, err := b.messageRuleValidater(desc)
 		if err != nil {
 			return types.NewErr(err.Error())
 		}
-		if err = messageValidater.ValidateWithMask(context.TODO(), msg, fm); err != nil {
+		if err = messageValidater.ValidateWithMask(contextFromVal(args[2]), msg, fm); err != nil {
 			if vErr, ok := err.(ref.Val); ok {
 				return vErr
 			}
@@ -7,6 +7,8 @@ import (
 	"github.com/google/cel-go/cel"
 	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
@@ -54,7 +56,7 @@ func TestDefaultOverloadBuilder(t *testing.T) {
 				if err != nil {
 					t.Error(err)
 				} else {
-					val, _, err := pgr.ContextEval(context.Background(), map[string]interface{}{"myvar": tt.Message, "fm": &fieldmaskpb.FieldMask{Paths: []string{"*"}}})
+					val, _, err := pgr.ContextEval(context.Background(), map[string]interface{}{"myvar": tt.Message, "fm": &fieldmaskpb.FieldMask{Paths: []string{"*"}}, contextVariable: newContextValue(context.Background())})
 					if err == nil {
 						if e, ok := val.Value().(error); ok {
 							err = e
@@ -118,7 +120,7 @@ func TestFallbackOverloadBuilder(t *testing.T) {
 				if err != nil {
 					t.Error(err)
 				} else {
-					val, _, err := pgr.ContextEval(context.Background(), map[string]interface{}{"myvar": tt.Message, "fm": &fieldmaskpb.FieldMask{Paths: []string{"*"}}})
+					val, _, err := pgr.ContextEval(context.Background(), map[string]interface{}{"myvar": tt.Message, "fm": &fieldmaskpb.FieldMask{Paths: []string{"*"}}, contextVariable: newContextValue(context.Background())})
 					if err == nil {
 						if e, ok := val.Value().(error); ok {
 							err = e
@@ -136,3 +138,133 @@ func TestFallbackOverloadBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestOverloadBuilderContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		Name        string
+		Ctx         context.Context
+		WantEvalErr bool
+	}{
+		{
+			Name: "Active context",
+			Ctx:  context.Background(),
+		},
+		{
+			Name:        "Cancelled context",
+			Ctx:         ctx,
+			WantEvalErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			msg := &validate.TestRpcRequest{Ref: "refs/myref", Raw: "raw"}
+			opts := (&fallbackOverloadBuilder{
+				Builder: newBuilder(),
+			}).buildOverloads(msg.ProtoReflect().Descriptor())
+			opts = append(opts, cel.TypeDescs(msg.ProtoReflect().Descriptor().ParentFile()),
+				cel.TypeDescs(fieldmaskpb.File_google_protobuf_field_mask_proto),
+				cel.Variable("fm", cel.ObjectType(string((&fieldmaskpb.FieldMask{}).ProtoReflect().Descriptor().FullName()))),
+				cel.Variable("myvar", cel.ObjectType(string(msg.ProtoReflect().Descriptor().FullName()))),
+			)
+			env, err := cel.NewEnv(opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, expr := range []string{`myvar.validate()`, `myvar.validateWithMask(fm)`} {
+				ast, issues := env.Compile(expr)
+				if issues != nil && issues.Err() != nil {
+					t.Fatal(issues.Err())
+				}
+				pgr, err := env.Program(ast)
+				if err != nil {
+					t.Fatal(err)
+				}
+				val, _, err := pgr.Eval(map[string]interface{}{"myvar": msg, "fm": &fieldmaskpb.FieldMask{Paths: []string{"*"}}, contextVariable: newContextValue(tt.Ctx)})
+				if err == nil {
+					if e, ok := val.Value().(error); ok {
+						err = e
+					}
+				}
+				if (tt.WantEvalErr && err == nil) || (!tt.WantEvalErr && err != nil) {
+					t.Errorf("wantEvalErr %v, got %v", tt.WantEvalErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestFallbackOverloadBuilderNestedBuiltOnce(t *testing.T) {
+	config := &Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+		"testdata.TestRpcRequest": {Rule: &Rule{Programs: []*Rule_Program{{Expr: `nested.validate()`}}}},
+		"testdata.Nested": {FieldRules: map[string]*FieldRule{
+			"name": {Rule: &Rule{Programs: []*Rule_Program{{Expr: `name == "ok"`}}}},
+		}},
+	}}}
+	m := dynamicpb.NewMessage((&validate.TestRpcRequest{}).ProtoReflect().Descriptor())
+	nested := dynamicpb.NewMessage((&validate.Nested{}).ProtoReflect().Descriptor())
+	nested.Set(nested.Descriptor().Fields().ByName("name"), protoreflect.ValueOfString("ko"))
+	m.Set(m.Descriptor().Fields().ByName("nested"), protoreflect.ValueOfMessage(nested))
+	tests := []struct {
+		Name string
+		// New returns the validation of the message, and the cached validater
+		// of the nested message
+		New func(t *testing.T) (func() error, func() interface{})
+	}{
+		{
+			Name: "Builder",
+			New: func(t *testing.T) (func() error, func() interface{}) {
+				b := newBuilder()
+				WithConfiguration(config).apply(b)
+				ob := &fallbackOverloadBuilder{Builder: b}
+				b.ob = ob
+				v, err := b.BuildMessageRuleValidater(m.Descriptor())
+				if err != nil {
+					t.Fatal(err)
+				}
+				return func() error {
+						return v.ValidateWithMask(context.Background(), m, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+					}, func() interface{} {
+						v, _ := ob.validaters.Load(nested.Descriptor().FullName())
+						return v
+					}
+			},
+		},
+		{
+			Name: "Runtime",
+			New: func(t *testing.T) (func() error, func() interface{}) {
+				r := NewRuntime(WithConfiguration(config))
+				return func() error {
+						return r.Validate(context.Background(), m)
+					}, func() interface{} {
+						manager := r.manager(nested.Descriptor().ParentFile())
+						manager.mu.RLock()
+						defer manager.mu.RUnlock()
+						if v := manager.messageValidaters[string(nested.Descriptor().FullName())].rv; v != nil {
+							return v
+						}
+						return nil
+					}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			validate, cached := tt.New(t)
+			if err := validate(); err == nil {
+				t.Fatalf("want error")
+			}
+			first := cached()
+			if first == nil {
+				t.Fatalf("want the nested validater to be cached")
+			}
+			if err := validate(); err == nil {
+				t.Fatalf("want error")
+			}
+			if cached() != first {
+				t.Errorf("want the nested validater built once")
+			}
+		})
+	}
+}
@@ -5,18 +5,29 @@ import (
 	"fmt"
 
 	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
+// interruptCheckFrequency is the number of comprehension iterations between two
+// checks of the context cancellation
+const interruptCheckFrequency = 100
+
 type Validater interface {
 	Validate(ctx context.Context) error
 	ValidateWithMask(ctx context.Context, fm *fieldmaskpb.FieldMask) error
 }
 
 type ValidateProgram struct {
-	Id      string
-	Expr    string
-	Program cel.Program
+	Id            string
+	Expr          string
+	Program       cel.Program
+	EstimatedCost checker.CostEstimate
+	Source        Source
+	Mode          Rule_Program_Mode
+	costLimit     uint64
+	explainer     *explainer
+	redact        bool
 }
 
 type RuleValidater interface {
@@ -30,7 +41,11 @@ type ruleValidater struct {
 func (v *ruleValidater) Programs() []*ValidateProgram { return v.programs }
 
 func BuildRuleValidater(rule *Rule, envOpt cel.EnvOption) (RuleValidater, error) {
-	envOpts := []cel.EnvOption{cel.Types(&fieldmaskpb.FieldMask{})}
+	return buildRuleValidater(rule, envOpt, &costEstimator{})
+}
+
+func buildRuleValidater(rule *Rule, envOpt cel.EnvOption, estimator *costEstimator) (RuleValidater, error) {
+	envOpts := []cel.EnvOption{cel.Types(&fieldmaskpb.FieldMask{}), cel.EnableMacroCallTracking()}
 	if envOpt != nil {
 		envOpts = append(envOpts, envOpt)
 	}
@@ -56,16 +71,42 @@ func BuildRuleValidater(rule *Rule, envOpt cel.EnvOption) (RuleValidater, error)
 			if !ast.OutputType().IsAssignableType(cel.BoolType) {
 				return nil, fmt.Errorf("output type not bool")
 			}
-			pgr, err := env.Program(ast, cel.EvalOptions(cel.OptOptimize))
+			cost, err := env.EstimateCost(ast, estimator)
+			if err != nil {
+				return nil, fmt.Errorf("estimate cost error: %w", err)
+			} else if estimator.maxCost > 0 && cost.Max > estimator.maxCost {
+				return nil, fmt.Errorf("estimate cost error: program %q (%s) has an estimated cost of %d, exceeding %d", rawProgram.Id, rawProgram.Expr, cost.Max, estimator.maxCost)
+			}
+			pgrOpts := []cel.ProgramOption{cel.InterruptCheckFrequency(interruptCheckFrequency)}
+			var costLimit uint64
+			if rule.Options != nil && rule.Options.CostLimit > 0 {
+				costLimit = rule.Options.CostLimit
+				pgrOpts = append(pgrOpts, cel.CostLimit(costLimit))
+			}
+			pgr, err := env.Program(ast, append(pgrOpts, cel.EvalOptions(cel.OptOptimize))...)
 			if err != nil {
 				return nil, fmt.Errorf("program error: %w", err)
 			}
 			validater.programs = append(validater.programs, &ValidateProgram{
-				Id:      rawProgram.Id,
-				Expr:    rawProgram.Expr,
-				Program: pgr,
+				Id:            rawProgram.Id,
+				Expr:          rawProgram.Expr,
+				Program:       pgr,
+				EstimatedCost: cost,
+				Mode:          rawProgram.Mode,
+				costLimit:     costLimit,
+				explainer:     &explainer{env: env, ast: ast, opts: pgrOpts},
 			})
 		}
 	}
 	return validater, nil
 }
+
+// clamped returns the program to evaluate with the remaining cost budget of
+// the validation: the program itself, or a program limited to the budget
+// when the budget is lower than its own limit and its estimated cost
+func (p *ValidateProgram) clamped(remaining uint64) (cel.Program, error) {
+	if (p.costLimit > 0 && p.costLimit <= remaining) || p.EstimatedCost.Max <= remaining || p.explainer == nil {
+		return p.Program, nil
+	}
+	return p.explainer.env.Program(p.explainer.ast, append(append([]cel.ProgramOption{}, p.explainer.opts...), cel.CostLimit(remaining))...)
+}
@@ -3,14 +3,23 @@ package validate
 import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/types/dynamicpb"
 )
 
-// GetExtension wraps proto.GetExtension function with dynamicpb.Message support
+// GetExtension wraps proto.GetExtension function with dynamicpb.Message and
+// unresolved extensions support
 func GetExtension(m protoreflect.ProtoMessage, xt protoreflect.ExtensionType) interface{} {
 	if m == nil {
 		return proto.GetExtension(m, xt)
 	}
+	if !m.ProtoReflect().Has(xt.TypeDescriptor()) && len(m.ProtoReflect().GetUnknown()) > 0 {
+		// options parsed without the extension type keep it in the unknown fields
+		mm := m.ProtoReflect().New().Interface()
+		if raw, err := proto.Marshal(m); err == nil && (proto.UnmarshalOptions{Resolver: protoregistry.GlobalTypes}).Unmarshal(raw, mm) == nil {
+			m = mm
+		}
+	}
 	switch t := m.ProtoReflect().Get(xt.TypeDescriptor()).Interface().(type) {
 	case *dynamicpb.Message:
 		mm := xt.InterfaceOf(xt.New()).(proto.Message)
@@ -5,6 +5,9 @@ import (
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
 	"google.golang.org/protobuf/types/known/sourcecontextpb"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/typepb"
@@ -201,3 +204,63 @@ func TestIsDefaultValue(t *testing.T) {
 		})
 	}
 }
+
+func TestGetExtension(t *testing.T) {
+	rule := &MessageRule{Rule: &Rule{Programs: []*Rule_Program{{Expr: `name != ""`}}}}
+	opts := &descriptorpb.MessageOptions{}
+	proto.SetExtension(opts, E_Message, rule)
+	raw, err := proto.Marshal(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unresolvedOpts := &descriptorpb.MessageOptions{}
+	if err = (proto.UnmarshalOptions{Resolver: &protoregistry.Types{}}).Unmarshal(raw, unresolvedOpts); err != nil {
+		t.Fatal(err)
+	}
+	dynamicTypes := &protoregistry.Types{}
+	if err = dynamicTypes.RegisterExtension(dynamicpb.NewExtensionType(E_Message.TypeDescriptor().Descriptor())); err != nil {
+		t.Fatal(err)
+	}
+	dynamicOpts := &descriptorpb.MessageOptions{}
+	if err = (proto.UnmarshalOptions{Resolver: dynamicTypes}).Unmarshal(raw, dynamicOpts); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		Name    string
+		Options proto.Message
+		Want    *MessageRule
+	}{
+		{
+			Name:    "Nil",
+			Options: (*descriptorpb.MessageOptions)(nil),
+			Want:    nil,
+		},
+		{
+			Name:    "Missing",
+			Options: &descriptorpb.MessageOptions{},
+			Want:    nil,
+		},
+		{
+			Name:    "Resolved",
+			Options: opts,
+			Want:    rule,
+		},
+		{
+			Name:    "Unresolved",
+			Options: unresolvedOpts,
+			Want:    rule,
+		},
+		{
+			Name:    "Dynamic",
+			Options: dynamicOpts,
+			Want:    rule,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			if got := GetExtension(tt.Options, E_Message).(*MessageRule); !proto.Equal(got, tt.Want) {
+				t.Errorf("want %v, got %v", tt.Want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package validate
+
+import (
+	"github.com/google/cel-go/cel"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// sensitiveField reports whether the values of the field must not be
+// disclosed, because of its debug_redact option or of a sensitive field rule
+func (b *builder) sensitiveField(fd protoreflect.FieldDescriptor) bool {
+	if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts.GetDebugRedact() {
+		return true
+	}
+	if GetExtension(fd.Options(), E_Field).(*FieldRule).GetSensitive() {
+		return true
+	}
+	message, name := string(fd.ContainingMessage().FullName()), string(fd.Name())
+	if GetExtension(fd.ContainingMessage().Options(), E_Message).(*MessageRule).GetFieldRules()[name].GetSensitive() {
+		return true
+	}
+	if GetExtension(fd.ParentFile().Options(), E_File).(*FileRule).GetMessageRules()[message].GetFieldRules()[name].GetSensitive() {
+		return true
+	}
+	return b.opts.GetRule().GetMessageRules()[message].GetFieldRules()[name].GetSensitive()
+}
+
+// redactPrograms marks the programs depending on a sensitive field, whose
+// evaluation errors and explanations must not disclose its values
+func (b *builder) redactPrograms(desc protoreflect.Descriptor, rv RuleValidater) {
+	for _, p := range rv.Programs() {
+		if p.explainer == nil {
+			continue
+		}
+		p.explainer.desc, p.explainer.sensitive = desc, b.sensitiveField
+		if checked, err := cel.AstToCheckedExpr(p.explainer.ast); err == nil {
+			p.redact = newExplainWalker(checked, desc, b.sensitiveField).walk(checked.Expr, false)
+		}
+	}
+}
+
+// redact returns the message without the values of its sensitive fields,
+// nested ones included, so that the errors given to the callers, observers and
+// listeners do not disclose them. The message is only copied when needed.
+func (e *evaluation) redact(m proto.Message) proto.Message {
+	if m == nil || e.sensitive == nil || !redactFields(m.ProtoReflect(), e.sensitive, false) {
+		return m
+	}
+	m = proto.Clone(m)
+	redactFields(m.ProtoReflect(), e.sensitive, true)
+	return m
+}
+
+// redactFields reports whether the message has sensitive fields set, and
+// clears them if asked to
+func redactFields(m protoreflect.Message, sensitive func(fd protoreflect.FieldDescriptor) bool, clear bool) bool {
+	found := false
+	cleared := []protoreflect.FieldDescriptor{}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case sensitive(fd):
+			found = true
+			cleared = append(cleared, fd)
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				found = redactFields(v.Message(), sensitive, clear) || found
+				return clear || !found
+			})
+		case fd.IsList() && fd.Message() != nil:
+			for i := 0; i < v.List().Len() && (clear || !found); i++ {
+				found = redactFields(v.List().Get(i).Message(), sensitive, clear) || found
+			}
+		case !fd.IsMap() && !fd.IsList() && fd.Message() != nil:
+			found = redactFields(v.Message(), sensitive, clear) || found
+		}
+		return clear || !found
+	})
+	if clear {
+		for _, fd := range cleared {
+			m.Clear(fd)
+		}
+	}
+	return found
+}
@@ -0,0 +1,198 @@
+package validate
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+type errorObserver struct {
+	mu     sync.Mutex
+	errors []string
+}
+
+func (o *errorObserver) ObserveProgram(ctx context.Context, event *ProgramEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if event.Err != nil {
+		o.errors = append(o.errors, event.Err.Error())
+	}
+}
+
+func (o *errorObserver) ObserveValidation(ctx context.Context, event *ValidationEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if event.Err != nil {
+		o.errors = append(o.errors, event.Err.Error())
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	tests := []struct {
+		Name       string
+		FieldRules map[string]map[string]*FieldRule
+		Expr       string
+		Message    proto.Message
+		Sensitive  bool
+	}{
+		{
+			Name:    "Not sensitive",
+			Expr:    `name.matches(name)`,
+			Message: &validate.MessageSensitive{Name: "hunter2("},
+		},
+		{
+			Name:      "Debug redact",
+			Expr:      `password.matches(password)`,
+			Message:   &validate.MessageSensitive{Password: "hunter2("},
+			Sensitive: true,
+		},
+		{
+			Name:      "Nested debug redact",
+			Expr:      `nested.secret.matches(nested.secret)`,
+			Message:   &validate.MessageSensitive{Nested: &validate.MessageSensitiveNested{Secret: "hunter2("}},
+			Sensitive: true,
+		},
+		{
+			Name:      "Repeated debug redact",
+			Expr:      `tokens.all(t, t.matches(t))`,
+			Message:   &validate.MessageSensitive{Tokens: []string{"1", "hunter2("}},
+			Sensitive: true,
+		},
+		{
+			Name: "Sensitive rule",
+			FieldRules: map[string]map[string]*FieldRule{
+				"testdata.validate.MessageSensitive": {"name": {Sensitive: true}},
+			},
+			Expr:      `name.matches(name)`,
+			Message:   &validate.MessageSensitive{Name: "hunter2("},
+			Sensitive: true,
+		},
+		{
+			Name: "Nested sensitive rule",
+			FieldRules: map[string]map[string]*FieldRule{
+				"testdata.validate.MessageSensitiveNested": {"label": {Sensitive: true}},
+			},
+			Expr:      `nested.label.size() > 0 && nested.label.matches(nested.label)`,
+			Message:   &validate.MessageSensitive{Nested: &validate.MessageSensitiveNested{Label: "hunter2("}},
+			Sensitive: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			config := &Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+				"testdata.validate.MessageSensitive": {Rule: &Rule{Programs: []*Rule_Program{{Id: "redacted", Expr: tt.Expr}}}},
+			}}}
+			for message, fieldRules := range tt.FieldRules {
+				if mr, ok := config.Rule.MessageRules[message]; ok {
+					mr.FieldRules = fieldRules
+				} else {
+					config.Rule.MessageRules[message] = &MessageRule{FieldRules: fieldRules}
+				}
+			}
+			observer := &errorObserver{}
+			desc := tt.Message.ProtoReflect().Descriptor()
+			v, err := newManager(desc.ParentFile(), WithConfiguration(config), WithExplain(), WithObserver(observer)).GetMessageRuleValidater(desc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = v.ValidateWithMask(context.Background(), tt.Message, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+			vErr, ok := err.(errors.ValidateError)
+			if !ok {
+				t.Fatalf("want validate error, got %v", err)
+			}
+			texts := append([]string{vErr.Error(), vErr.GetExplanation().String()}, observer.errors...)
+			for _, text := range texts {
+				if leaked := strings.Contains(text, "hunter2"); leaked == tt.Sensitive {
+					t.Errorf("want sensitive %v, got %q", tt.Sensitive, text)
+				}
+			}
+		})
+	}
+}
+
+func TestBuilderSensitiveField(t *testing.T) {
+	desc := validate.File_testdata_validate_message_proto.Messages().ByName("MessageSensitive")
+	b := newBuilder()
+	b.opts = &Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+		"testdata.validate.MessageSensitive": {FieldRules: map[string]*FieldRule{"name": {Sensitive: true}}},
+	}}}
+	want := map[string]bool{"name": true, "password": true, "nested": false, "tokens": true}
+	for name, sensitive := range want {
+		if got := b.sensitiveField(desc.Fields().ByTextName(name)); got != sensitive {
+			t.Errorf("%s: want %v, got %v", name, sensitive, got)
+		}
+	}
+}
+
+type messageObserver struct {
+	mu       sync.Mutex
+	messages []proto.Message
+}
+
+func (o *messageObserver) ObserveProgram(ctx context.Context, event *ProgramEvent) {
+	o.observe(event.Err)
+}
+
+func (o *messageObserver) ObserveValidation(ctx context.Context, event *ValidationEvent) {
+	o.observe(event.Err)
+}
+
+func (o *messageObserver) observe(err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if vErr, ok := err.(errors.ValidateError); ok {
+		o.messages = append(o.messages, vErr.GetMessage())
+	}
+}
+
+func TestRedactionMessage(t *testing.T) {
+	config := &Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+		"testdata.validate.MessageSensitive": {Rule: &Rule{Programs: []*Rule_Program{{Expr: `name == "name"`}}}},
+	}}}
+	observer := &messageObserver{}
+	var violations []proto.Message
+	remove := AddViolationListener(func(ctx context.Context, err errors.ValidateError) {
+		violations = append(violations, err.GetMessage())
+	})
+	defer remove()
+	m := &validate.MessageSensitive{
+		Name:     "other",
+		Password: "hunter2",
+		Tokens:   []string{"hunter2"},
+		Nested:   &validate.MessageSensitiveNested{Label: "label", Secret: "hunter2"},
+	}
+	desc := m.ProtoReflect().Descriptor()
+	v, err := newManager(desc.ParentFile(), WithConfiguration(config), WithObserver(observer)).GetMessageRuleValidater(desc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = v.ValidateWithMask(context.Background(), m, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+	vErr, ok := err.(errors.ValidateError)
+	if !ok {
+		t.Fatalf("want validate error, got %v", err)
+	}
+	messages := append([]proto.Message{vErr.GetMessage()}, append(observer.messages, violations...)...)
+	if len(messages) < 3 {
+		t.Fatalf("want the message of the error, of the events and of the violations, got %d", len(messages))
+	}
+	for _, message := range messages {
+		b, err := protojson.Marshal(message)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		} else if strings.Contains(string(b), "hunter2") {
+			t.Errorf("want the sensitive values redacted, got %s", b)
+		} else if !strings.Contains(string(b), "label") {
+			t.Errorf("want the other values kept, got %s", b)
+		}
+	}
+	if m.Password != "hunter2" || m.Nested.Secret != "hunter2" {
+		t.Errorf("validated message modified")
+	}
+}
@@ -0,0 +1,203 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// CheckConfiguration checks that the services, methods, messages and fields
+// referenced by name in the configuration and the file options exist. The
+// unresolved references of the package of the file are returned as an error,
+// or given to the warning handler when the configuration is lenient. The
+// other ones, which may belong to files built separately, are only warned.
+func (m *Manager) CheckConfiguration() error {
+	b := m.builder()
+	c := &referenceChecker{file: m.file, local: &protoregistry.Files{}, files: b.files}
+	if c.files == nil {
+		c.files = protoregistry.GlobalFiles
+	}
+	c.local.RegisterFile(m.file)
+	if b.opts != nil {
+		c.checkFileRule(b.opts.Rule)
+	}
+	c.checkFileRule(GetExtension(m.file.Options(), E_File).(*FileRule))
+	for i := 0; i < m.file.Services().Len(); i++ {
+		sd := m.file.Services().Get(i)
+		c.checkServiceRule(sd, GetExtension(sd.Options(), E_Service).(*ServiceRule))
+	}
+	rangeMessageDescriptors(m.file.Messages(), func(md protoreflect.MessageDescriptor) {
+		c.checkMessageRule(md, GetExtension(md.Options(), E_Message).(*MessageRule))
+	})
+	if b.lenient {
+		c.unresolved, c.foreign = append(c.unresolved, c.foreign...), nil
+	}
+	if len(c.foreign) > 0 && b.warn != nil {
+		sort.Strings(c.foreign)
+		b.warn(fmt.Errorf("unresolved configuration references: %s", strings.Join(c.foreign, ", ")))
+	}
+	if len(c.unresolved) == 0 {
+		return nil
+	}
+	sort.Strings(c.unresolved)
+	err := fmt.Errorf("unresolved configuration references: %s", strings.Join(c.unresolved, ", "))
+	if b.lenient {
+		if b.warn != nil {
+			b.warn(err)
+		}
+		return nil
+	}
+	return err
+}
+
+// checkConfiguration checks the configuration when one of the configuration
+// check options is set
+func (m *Manager) checkConfiguration() error {
+	if !m.builder().checked {
+		return nil
+	}
+	return m.CheckConfiguration()
+}
+
+type referenceChecker struct {
+	file       protoreflect.FileDescriptor
+	local      *protoregistry.Files
+	files      *protoregistry.Files
+	unresolved []string
+	foreign    []string
+}
+
+// unresolve records the unresolved reference of the element named scope, as
+// foreign when scope belongs to another package than the file
+func (c *referenceChecker) unresolve(scope protoreflect.FullName, ref string) {
+	if pkg := c.file.Package(); pkg != "" && !strings.HasPrefix(string(scope), string(pkg)+".") {
+		c.foreign = append(c.foreign, ref)
+	} else {
+		c.unresolved = append(c.unresolved, ref)
+	}
+}
+
+func (c *referenceChecker) find(name string) protoreflect.Descriptor {
+	if d, err := c.local.FindDescriptorByName(protoreflect.FullName(name)); err == nil {
+		return d
+	} else if d, err := c.files.FindDescriptorByName(protoreflect.FullName(name)); err == nil {
+		return d
+	}
+	return nil
+}
+
+func (c *referenceChecker) checkFileRule(fr *FileRule) {
+	for name, sr := range fr.GetServiceRules() {
+		if sd, ok := c.find(name).(protoreflect.ServiceDescriptor); ok {
+			c.checkServiceRule(sd, sr)
+		} else {
+			c.unresolve(protoreflect.FullName(name), fmt.Sprintf("service %q%s", name, suggest(name, c.names(true))))
+		}
+	}
+	for name, mr := range fr.GetMessageRules() {
+		if md, ok := c.find(name).(protoreflect.MessageDescriptor); ok {
+			c.checkMessageRule(md, mr)
+		} else {
+			c.unresolve(protoreflect.FullName(name), fmt.Sprintf("message %q%s", name, suggest(name, c.names(false))))
+		}
+	}
+}
+
+func (c *referenceChecker) checkServiceRule(sd protoreflect.ServiceDescriptor, sr *ServiceRule) {
+	for name := range sr.GetMethodRules() {
+		if sd.Methods().ByName(protoreflect.Name(name)) == nil {
+			candidates := []string{}
+			for i := 0; i < sd.Methods().Len(); i++ {
+				candidates = append(candidates, string(sd.Methods().Get(i).Name()))
+			}
+			c.unresolve(sd.FullName(), fmt.Sprintf("method %q of service %q%s", name, sd.FullName(), suggest(name, candidates)))
+		}
+	}
+}
+
+func (c *referenceChecker) checkMessageRule(md protoreflect.MessageDescriptor, mr *MessageRule) {
+	for name := range mr.GetFieldRules() {
+		if md.Fields().ByName(protoreflect.Name(name)) == nil {
+			candidates := []string{}
+			for i := 0; i < md.Fields().Len(); i++ {
+				candidates = append(candidates, string(md.Fields().Get(i).Name()))
+			}
+			c.unresolve(md.FullName(), fmt.Sprintf("field %q of message %q%s", name, md.FullName(), suggest(name, candidates)))
+		}
+	}
+}
+
+// names returns the full names of the services, or of the messages, known by
+// the checker
+func (c *referenceChecker) names(services bool) []string {
+	names := []string{}
+	add := func(fd protoreflect.FileDescriptor) bool {
+		if services {
+			for i := 0; i < fd.Services().Len(); i++ {
+				names = append(names, string(fd.Services().Get(i).FullName()))
+			}
+		} else {
+			rangeMessageDescriptors(fd.Messages(), func(md protoreflect.MessageDescriptor) {
+				names = append(names, string(md.FullName()))
+			})
+		}
+		return true
+	}
+	add(c.file)
+	c.files.RangeFiles(add)
+	return names
+}
+
+func rangeMessageDescriptors(msgs protoreflect.MessageDescriptors, fn func(md protoreflect.MessageDescriptor)) {
+	for i := 0; i < msgs.Len(); i++ {
+		if md := msgs.Get(i); !md.IsMapEntry() {
+			fn(md)
+			rangeMessageDescriptors(md.Messages(), fn)
+		}
+	}
+}
+
+// suggest returns a hint with the closest candidate, if close enough to be a
+// misspelling of the name
+func suggest(name string, candidates []string) string {
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		if d := editDistance(name, candidate); bestDistance < 0 || d < bestDistance || (d == bestDistance && candidate < best) {
+			best, bestDistance = candidate, d
+		}
+	}
+	if bestDistance < 0 || (bestDistance > 2 && bestDistance > len(name)/4) {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if v := prev[j] + 1; v < cur[j] {
+				cur[j] = v
+			}
+			if v := cur[j-1] + 1; v < cur[j] {
+				cur[j] = v
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,121 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+)
+
+func TestCheckConfiguration(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Config   *Configuration
+		Lenient  bool
+		WantErr  string
+		WantWarn bool
+	}{
+		{
+			Name: "OK",
+			Config: &Configuration{Rule: &FileRule{
+				ServiceRules: map[string]*ServiceRule{
+					"testdata.validate.Manager": {MethodRules: map[string]*MethodRule{"ManagerRpc": {}}},
+				},
+				MessageRules: map[string]*MessageRule{
+					"testdata.validate.ManagerRpcRequest": {FieldRules: map[string]*FieldRule{"name": {}}},
+					"google.protobuf.Empty":               {},
+				},
+			}},
+		},
+		{
+			Name: "Unknown service",
+			Config: &Configuration{Rule: &FileRule{
+				ServiceRules: map[string]*ServiceRule{"testdata.validate.Manger": {}},
+			}},
+			WantErr: `unresolved configuration references: service "testdata.validate.Manger" (did you mean "testdata.validate.Manager"?)`,
+		},
+		{
+			Name: "Unknown method and field",
+			Config: &Configuration{Rule: &FileRule{
+				ServiceRules: map[string]*ServiceRule{
+					"testdata.validate.Manager": {MethodRules: map[string]*MethodRule{"ManagerRcp": {}}},
+				},
+				MessageRules: map[string]*MessageRule{
+					"testdata.validate.ManagerRpcRequest": {FieldRules: map[string]*FieldRule{"nmae": {}, "unrelated": {}}},
+				},
+			}},
+			WantErr: `unresolved configuration references: field "nmae" of message "testdata.validate.ManagerRpcRequest" (did you mean "name"?), field "unrelated" of message "testdata.validate.ManagerRpcRequest", method "ManagerRcp" of service "testdata.validate.Manager" (did you mean "ManagerRpc"?)`,
+		},
+		{
+			Name: "Unknown message of another package",
+			Config: &Configuration{Rule: &FileRule{
+				ServiceRules: map[string]*ServiceRule{"other.Service": {}},
+				MessageRules: map[string]*MessageRule{
+					"other.Message":         {},
+					"google.protobuf.Empty": {FieldRules: map[string]*FieldRule{"unknown": {}}},
+				},
+			}},
+			WantWarn: true,
+		},
+		{
+			Name: "Unknown message (lenient)",
+			Config: &Configuration{Rule: &FileRule{
+				MessageRules: map[string]*MessageRule{"testdata.validate.Unknown": {}},
+			}},
+			Lenient:  true,
+			WantWarn: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			var warning error
+			opts := []ManagerOption{WithConfiguration(tt.Config)}
+			if tt.Lenient {
+				opts = append(opts, WithLenientConfiguration(func(err error) { warning = err }))
+			} else {
+				opts = append(opts, WithConfigurationWarnings(func(err error) { warning = err }))
+			}
+			err := newManager(validate.File_testdata_validate_manager_proto, opts...).CheckConfiguration()
+			if tt.WantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if tt.WantErr != "" && (err == nil || err.Error() != tt.WantErr) {
+				t.Errorf("want %s, got %v", tt.WantErr, err)
+			}
+			if (warning != nil) != tt.WantWarn {
+				t.Errorf("wantWarn %v, got %v", tt.WantWarn, warning)
+			}
+		})
+	}
+}
+
+func TestBuildValidatersConfigurationCheck(t *testing.T) {
+	config := &Configuration{Rule: &FileRule{
+		Options:      &Options{Globals: &Options_Globals{Constants: map[string]string{"name_const": "name"}}},
+		MessageRules: map[string]*MessageRule{"testdata.validate.Unknown": {}},
+	}}
+	tests := []struct {
+		Name    string
+		Opts    []ManagerOption
+		WantErr bool
+	}{
+		{
+			Name: "Not checked",
+		},
+		{
+			Name:    "Checked",
+			Opts:    []ManagerOption{WithConfigurationWarnings(nil)},
+			WantErr: true,
+		},
+		{
+			Name: "Lenient",
+			Opts: []ManagerOption{WithLenientConfiguration(nil)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			m := newManager(validate.File_testdata_validate_manager_proto, append([]ManagerOption{WithConfiguration(config)}, tt.Opts...)...)
+			if err := m.BuildValidaters(); err != nil != tt.WantErr {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+// Reload builds every validater of the file again, with the configurations
+// merged rule by rule over the one the manager was created with, and swaps them
+// atomically. When a validater cannot be built, the previous ones are kept.
+// Calling Reload without configuration restores the initial rules.
+func (m *Manager) Reload(cfgs ...*Configuration) error {
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+	next, err := m.prepareReload(cfgs...)
+	if err != nil {
+		return err
+	}
+	m.commitReload(next)
+	return nil
+}
+
+// prepareReload builds the validaters of the reloaded manager, without
+// swapping them. The reload lock must be held.
+func (m *Manager) prepareReload(cfgs ...*Configuration) (*Manager, error) {
+	b := m.builder()
+	if !m.reloaded {
+		m.base = b.opts
+	}
+	opts := &Configuration{}
+	if m.base != nil {
+		opts = proto.Clone(m.base).(*Configuration)
+	}
+	for _, c := range cfgs {
+		if err := mergeConfiguration(opts, c); err != nil {
+			return nil, fmt.Errorf("reload %s: %w", m.file.Path(), err)
+		}
+	}
+	next := newManager(m.file)
+	next.b = b.clone()
+	next.b.opts = opts
+	if err := next.buildAll(); err != nil {
+		return nil, fmt.Errorf("reload %s: %w", m.file.Path(), err)
+	}
+	return next, nil
+}
+
+func (m *Manager) commitReload(next *Manager) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.b, m.onces = next.b, next.onces
+	m.serviceValidaters, m.messageValidaters = next.serviceValidaters, next.messageValidaters
+	m.reloaded = true
+}
+
+// buildAll checks the configuration, if asked to, and builds the validaters
+// of every service and message of the file, nested messages included
+func (m *Manager) buildAll() error {
+	if err := m.checkConfiguration(); err != nil {
+		return err
+	}
+	for i := 0; i < m.file.Services().Len(); i++ {
+		if _, err := m.GetServiceRuleValidater(m.file.Services().Get(i)); err != nil {
+			return err
+		}
+	}
+	var err error
+	rangeMessageDescriptors(m.file.Messages(), func(md protoreflect.MessageDescriptor) {
+		if err == nil {
+			_, err = m.GetMessageRuleValidater(md)
+		}
+	})
+	return err
+}
+
+func (m *Manager) builder() *builder {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.b
+}
+
+// clone returns a copy of the builder, with its own fallback overloads
+func (b *builder) clone() *builder {
+	c := *b
+	if _, ok := b.ob.(*fallbackOverloadBuilder); ok {
+		c.ob = &fallbackOverloadBuilder{Builder: &c}
+	}
+	return &c
+}
+
+// Reload reloads the managers of the packages matching the pattern, like
+// LoadOptions. The managers are only swapped when all of them were built.
+func Reload(pattern string, cfgs ...*Configuration) error {
+	return registry.Reload(pattern, cfgs...)
+}
+
+func (r *managerRegistry) Reload(pattern string, cfgs ...*Configuration) error {
+	managers := []*Manager{}
+	for _, m := range r.Managers() {
+		if ok, _ := filepath.Match(pattern, string(m.file.Package())); ok {
+			managers = append(managers, m)
+		}
+	}
+	for _, m := range managers {
+		m.reloadMu.Lock()
+		defer m.reloadMu.Unlock()
+	}
+	nexts := make([]*Manager, len(managers))
+	for i, m := range managers {
+		next, err := m.prepareReload(cfgs...)
+		if err != nil {
+			return err
+		}
+		nexts[i] = next
+	}
+	for i, m := range managers {
+		m.commitReload(nexts[i])
+	}
+	return nil
+}
+
+// LoadConfigurationFile reads a configuration file, in the protobuf JSON
+// format when its extension is .json and in YAML otherwise
+func LoadConfigurationFile(path string) (*Configuration, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Configuration{}
+	if filepath.Ext(path) == ".json" {
+		err = protojson.Unmarshal(b, c)
+	} else {
+		err = yaml.Unmarshal(b, &c)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+	return c, nil
+}
+
+// ReloadFile reloads the managers of the packages matching the pattern with
+// the configuration file
+func ReloadFile(pattern string, path string) error {
+	c, err := LoadConfigurationFile(path)
+	if err != nil {
+		return err
+	}
+	return Reload(pattern, c)
+}
+
+// WatchFile reloads the managers of the packages matching the pattern each
+// time the configuration file is modified, checking it every interval until
+// the context is done. The errors are given to onError, if not nil, and the
+// previous rules are kept.
+func WatchFile(ctx context.Context, pattern string, path string, interval time.Duration, onError func(err error)) {
+	var modTime time.Time
+	var size int64 = -1
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if info, err := os.Stat(path); err != nil {
+			if onError != nil {
+				onError(err)
+			}
+		} else if !info.ModTime().Equal(modTime) || info.Size() != size {
+			modTime, size = info.ModTime(), info.Size()
+			if err := ReloadFile(pattern, path); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
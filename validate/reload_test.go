@@ -0,0 +1,160 @@
+package validate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate/option"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func nameConfiguration(name string) *Configuration {
+	return &Configuration{Rule: &FileRule{Options: &Options{Globals: &Options_Globals{Constants: map[string]string{"name_const": name}}}}}
+}
+
+func TestManagerReload(t *testing.T) {
+	m := newManager(validate.File_testdata_validate_manager_proto, WithConfiguration(nameConfiguration("name")))
+	msg := &validate.ManagerRpcRequest{Name: "other"}
+	validateMsg := func() error {
+		v, err := m.GetMessageRuleValidater(msg.ProtoReflect().Descriptor())
+		if err != nil {
+			return err
+		}
+		return v.ValidateWithMask(context.Background(), msg, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+	}
+	tests := []struct {
+		Name          string
+		Configuration []*Configuration
+		WantErr       bool
+		WantValid     bool
+	}{
+		{
+			Name:          "Override",
+			Configuration: []*Configuration{nameConfiguration("other")},
+			WantValid:     true,
+		},
+		{
+			Name: "Compilation error",
+			Configuration: []*Configuration{{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+				"testdata.validate.ManagerRpcRequest": {Rule: &Rule{Programs: []*Rule_Program{{Expr: "unknown"}}}},
+			}}}},
+			WantErr:   true,
+			WantValid: true,
+		},
+		{
+			Name:      "Restore",
+			WantValid: false,
+		},
+	}
+	if err := validateMsg(); err == nil {
+		t.Fatal("want error")
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			if err := m.Reload(tt.Configuration...); (err != nil) != tt.WantErr {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			}
+			if err := validateMsg(); (err == nil) != tt.WantValid {
+				t.Errorf("wantValid %v, got %v", tt.WantValid, err)
+			}
+		})
+	}
+}
+
+func TestManagerReloadMergesRules(t *testing.T) {
+	fieldConfiguration := func(field, expr string) *Configuration {
+		return &Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+			"testdata.Nested": {FieldRules: map[string]*FieldRule{
+				field: {Rule: &Rule{Programs: []*Rule_Program{{Expr: expr}}}},
+			}},
+		}}}
+	}
+	m := newManager(validate.File_testdata_validate_test_proto, WithConfiguration(fieldConfiguration("name", `name != "bad"`)))
+	if err := m.Reload(fieldConfiguration("value", `value != "bad"`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		Name    string
+		Message *validate.Nested
+		WantErr bool
+	}{
+		{
+			Name:    "Base rule",
+			Message: &validate.Nested{Name: "bad"},
+			WantErr: true,
+		},
+		{
+			Name:    "Reloaded rule",
+			Message: &validate.Nested{Name: "name", Value: "bad"},
+			WantErr: true,
+		},
+		{
+			Name:    "OK",
+			Message: &validate.Nested{Name: "name", Value: "value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			v, err := m.GetMessageRuleValidater(tt.Message.ProtoReflect().Descriptor())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err = v.ValidateWithMask(context.Background(), tt.Message, &fieldmaskpb.FieldMask{Paths: []string{"*"}}); (err != nil) != tt.WantErr {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			}
+		})
+	}
+}
+
+func TestWatchFile(t *testing.T) {
+	m, err := NewManager(option.File_testdata_validate_option_option_proto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := func() bool {
+		v, err := m.GetMessageRuleValidater((&option.OptionRequest{}).ProtoReflect().Descriptor())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v.ValidateWithMask(context.Background(), &option.OptionRequest{Name: "name"}, &fieldmaskpb.FieldMask{Paths: []string{"*"}}) == nil
+	}
+	if !valid() {
+		t.Fatal("want valid message")
+	}
+	path := filepath.Join(t.TempDir(), "config.json")
+	write := func(expr string) {
+		content := `{"rule": {"messageRules": {"testdata.option.OptionRequest": {"fieldRules": {"name": {"rule": {"programs": [{"expr": "` + expr + `"}]}}}}}}}`
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("size(name) < 3")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 10)
+	go WatchFile(ctx, "testdata.option", path, 10*time.Millisecond, func(err error) { errs <- err })
+	waitFor := func(want bool) {
+		for deadline := time.Now().Add(5 * time.Second); valid() != want; {
+			if time.Now().After(deadline) {
+				t.Fatalf("want valid %v", want)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	waitFor(false)
+	write("size(name) <")
+	select {
+	case <-errs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("want reload error")
+	}
+	if valid() {
+		t.Error("want previous rules to be kept")
+	}
+	write("size(name) < 10")
+	waitFor(true)
+}
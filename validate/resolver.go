@@ -0,0 +1,137 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter/functions"
+	"github.com/google/cel-go/parser"
+	v1alpha1 "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// Resolver provides data which is not part of the validated message, such as
+// the existence of a referenced resource. The context is the one given to the
+// Validate call.
+type Resolver interface {
+	Resolve(ctx context.Context, name string, args []ref.Val) (ref.Val, error)
+}
+
+type ResolverFunc func(ctx context.Context, name string, args []ref.Val) (ref.Val, error)
+
+func (f ResolverFunc) Resolve(ctx context.Context, name string, args []ref.Val) (ref.Val, error) {
+	return f(ctx, name, args)
+}
+
+type ResolverOption interface {
+	apply(r *resolver)
+}
+
+type resolverOption func(r *resolver)
+
+func (opt resolverOption) apply(r *resolver) { opt(r) }
+
+// WithResolverTimeout bounds the duration of each call to the resolver
+func WithResolverTimeout(timeout time.Duration) ResolverOption {
+	return resolverOption(func(r *resolver) {
+		r.timeout = timeout
+	})
+}
+
+// WithResolverCacheDisabled calls the resolver every time, instead of once per
+// validation for a given set of arguments
+func WithResolverCacheDisabled() ResolverOption {
+	return resolverOption(func(r *resolver) {
+		r.cacheDisabled = true
+	})
+}
+
+// WithResolver exposes the resolver as a CEL function named name, with the
+// given argument and result types
+func WithResolver(name string, args []*cel.Type, result *cel.Type, r Resolver, opts ...ResolverOption) ManagerOption {
+	return managerOption(func(b *builder) {
+		res := &resolver{name: name, args: args, result: result, resolver: r}
+		for _, opt := range opts {
+			opt.apply(res)
+		}
+		b.resolvers = append(b.resolvers, res)
+	})
+}
+
+type resolver struct {
+	name          string
+	args          []*cel.Type
+	result        *cel.Type
+	resolver      Resolver
+	timeout       time.Duration
+	cacheDisabled bool
+}
+
+func (r *resolver) resolve(args ...ref.Val) ref.Val {
+	ctx := contextFromVal(args[0])
+	args = args[1:]
+	var key string
+	state := validationStateFromContext(ctx)
+	if state != nil && !r.cacheDisabled {
+		parts := []string{r.name}
+		for _, arg := range args {
+			parts = append(parts, fmt.Sprintf("%s:%v", arg.Type().TypeName(), arg.Value()))
+		}
+		key = strings.Join(parts, "\x00")
+		state.mu.Lock()
+		val, ok := state.cache[key]
+		state.mu.Unlock()
+		if ok {
+			return val
+		}
+	}
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	val, err := r.resolver.Resolve(ctx, r.name, args)
+	if err != nil {
+		return types.NewErr("resolver %s error: %v", r.name, err)
+	} else if val == nil {
+		val = types.NullValue
+	}
+	if key != "" {
+		state.mu.Lock()
+		state.cache[key] = val
+		state.mu.Unlock()
+	}
+	return val
+}
+
+func (r *resolver) envOpts() []cel.EnvOption {
+	args := append([]*cel.Type{contextType}, r.args...)
+	var binding cel.OverloadOpt
+	switch len(args) {
+	case 1:
+		binding = cel.UnaryBinding(func(arg ref.Val) ref.Val { return r.resolve(arg) })
+	case 2:
+		binding = cel.BinaryBinding(func(lhs, rhs ref.Val) ref.Val { return r.resolve(lhs, rhs) })
+	default:
+		binding = cel.FunctionBinding(functions.FunctionOp(r.resolve))
+	}
+	return []cel.EnvOption{
+		cel.Function(r.name, cel.Overload(fmt.Sprintf("%s_resolver", r.name), args, r.result, binding)),
+		cel.Macros(parser.NewGlobalMacro(r.name, len(r.args), func(eh parser.ExprHelper, target *v1alpha1.Expr, args []*v1alpha1.Expr) (*v1alpha1.Expr, *common.Error) {
+			return eh.GlobalCall(r.name, append([]*v1alpha1.Expr{eh.Ident(contextVariable)}, args...)...), nil
+		})),
+	}
+}
+
+func buildContextEnvOption(resolvers []*resolver) cel.EnvOption {
+	envOpts := []cel.EnvOption{cel.Variable(contextVariable, contextType)}
+	for _, r := range resolvers {
+		envOpts = append(envOpts, r.envOpts()...)
+	}
+	return cel.Lib(&Library{EnvOpts: envOpts})
+}
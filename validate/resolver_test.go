@@ -0,0 +1,122 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+type resolverTestKey struct{}
+
+func TestResolver(t *testing.T) {
+	desc := validate.File_testdata_validate_field_proto.Messages().ByName("Field")
+	exists := ResolverFunc(func(ctx context.Context, name string, args []ref.Val) (ref.Val, error) {
+		if ctx.Value(resolverTestKey{}) == nil {
+			return nil, fmt.Errorf("missing context value")
+		}
+		return types.Bool(args[0].Value().(string) == "fields/exists"), nil
+	})
+	slow := ResolverFunc(func(ctx context.Context, name string, args []ref.Val) (ref.Val, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return types.True, nil
+		}
+	})
+	tests := []struct {
+		Name      string
+		Programs  []string
+		Resolver  Resolver
+		Opts      []ResolverOption
+		Value     string
+		WantErr   bool
+		WantCalls int32
+	}{
+		{
+			Name:      "OK",
+			Programs:  []string{`lookup(name)`},
+			Resolver:  exists,
+			Value:     "fields/exists",
+			WantCalls: 1,
+		},
+		{
+			Name:      "NOK",
+			Programs:  []string{`lookup(name)`},
+			Resolver:  exists,
+			Value:     "fields/unknown",
+			WantErr:   true,
+			WantCalls: 1,
+		},
+		{
+			Name:      "Cached result",
+			Programs:  []string{`lookup(name)`, `lookup(name) && lookup("fields/" + resourceVar(name, "field"))`},
+			Resolver:  exists,
+			Value:     "fields/exists",
+			WantCalls: 1,
+		},
+		{
+			Name:      "Cache disabled",
+			Programs:  []string{`lookup(name)`, `lookup(name)`},
+			Resolver:  exists,
+			Opts:      []ResolverOption{WithResolverCacheDisabled()},
+			Value:     "fields/exists",
+			WantCalls: 2,
+		},
+		{
+			Name:      "Timeout",
+			Programs:  []string{`lookup(name)`},
+			Resolver:  slow,
+			Opts:      []ResolverOption{WithResolverTimeout(10 * time.Millisecond)},
+			Value:     "fields/exists",
+			WantErr:   true,
+			WantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			var calls int32
+			counter := ResolverFunc(func(ctx context.Context, name string, args []ref.Val) (ref.Val, error) {
+				atomic.AddInt32(&calls, 1)
+				return tt.Resolver.Resolve(ctx, name, args)
+			})
+			programs := []*Rule_Program{}
+			for _, expr := range tt.Programs {
+				programs = append(programs, &Rule_Program{Expr: expr})
+			}
+			m, err := NewManager(validate.File_testdata_validate_field_proto,
+				WithConfiguration(&Configuration{
+					Rule: &FileRule{
+						MessageRules: map[string]*MessageRule{
+							string(desc.FullName()): {Rule: &Rule{Programs: programs}},
+						},
+					},
+				}),
+				WithResolver("lookup", []*cel.Type{cel.StringType}, cel.BoolType, counter, tt.Opts...),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rv, err := m.GetMessageRuleValidater(desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.WithValue(context.Background(), resolverTestKey{}, true)
+			err = rv.ValidateWithMask(ctx, &validate.Field{Name: tt.Value}, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+			if (tt.WantErr && err == nil) || (!tt.WantErr && err != nil) {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			}
+			if calls != tt.WantCalls {
+				t.Errorf("wantCalls %v, got %v", tt.WantCalls, calls)
+			}
+		})
+	}
+}
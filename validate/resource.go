@@ -4,6 +4,9 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
 	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -11,26 +14,157 @@ import (
 
 var patternRegexp = regexp.MustCompile(`\{[\w-]+\}`)
 var newPattern = `[\\w-\\.]+`
+var segmentRegexp = regexp.MustCompile(`^[\w-\.]+$`)
 
 func GenerateResourceTypePatternMapping(desc protoreflect.Descriptor) map[string]string {
+	m := map[string]string{}
+	for _, resource := range findResourceDescriptors(desc) {
+		p := []string{}
+		for _, pattern := range resource.Pattern {
+			p = append(p, patternRegexp.ReplaceAllString(pattern, newPattern))
+		}
+		m[resource.Type] = "(" + strings.Join(p, "|") + ")"
+	}
+	return m
+}
+
+func findResourceDescriptors(desc protoreflect.Descriptor) []*annotations.ResourceDescriptor {
 	imps := []protoreflect.FileDescriptor{desc.ParentFile()}
 	for i := 0; i < desc.ParentFile().Imports().Len(); i++ {
 		imps = append(imps, desc.ParentFile().Imports().Get(i))
 	}
-	m := map[string]string{}
+	res := []*annotations.ResourceDescriptor{}
 	for i := 0; i < len(imps); i++ {
 		messages := imps[i].Messages()
 		for j := 0; j < messages.Len(); j++ {
 			msg := messages.Get(j)
 			resource := proto.GetExtension(msg.Options(), annotations.E_Resource).(*annotations.ResourceDescriptor)
 			if resource != nil {
-				p := []string{}
-				for _, pattern := range resource.Pattern {
-					p = append(p, patternRegexp.ReplaceAllString(pattern, newPattern))
-				}
-				m[resource.Type] = "(" + strings.Join(p, "|") + ")"
+				res = append(res, resource)
 			}
 		}
 	}
-	return m
+	return res
+}
+
+// BuildResourceEnvOption declares the resourceType, resourceVar and resourceParent
+// functions, matching resource names against the google.api.resource patterns
+// visible from desc
+func BuildResourceEnvOption(desc protoreflect.Descriptor) cel.EnvOption {
+	patterns := []*resourcePattern{}
+	for _, resource := range findResourceDescriptors(desc) {
+		for _, pattern := range resource.Pattern {
+			patterns = append(patterns, newResourcePattern(resource.Type, pattern))
+		}
+	}
+	match := func(val ref.Val) (*resourcePattern, []string, ref.Val) {
+		name, ok := val.(types.String)
+		if !ok {
+			return nil, nil, types.MaybeNoSuchOverloadErr(val)
+		}
+		segments := strings.Split(string(name), "/")
+		for _, p := range patterns {
+			if p.Match(segments) {
+				return p, segments, nil
+			}
+		}
+		return nil, nil, types.NewErr(`no resource pattern matching "%s"`, name)
+	}
+	return cel.Lib(&Library{
+		EnvOpts: []cel.EnvOption{
+			cel.Function("resourceType",
+				cel.Overload("resourceType_string", []*cel.Type{cel.StringType}, cel.StringType,
+					cel.UnaryBinding(func(val ref.Val) ref.Val {
+						p, _, err := match(val)
+						if err != nil {
+							return err
+						}
+						return types.String(p.Type)
+					}),
+				),
+			),
+			cel.Function("resourceVar",
+				cel.Overload("resourceVar_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.StringType,
+					cel.BinaryBinding(func(lhs, rhs ref.Val) ref.Val {
+						name, ok := rhs.(types.String)
+						if !ok {
+							return types.MaybeNoSuchOverloadErr(rhs)
+						}
+						p, segments, err := match(lhs)
+						if err != nil {
+							return err
+						}
+						for i, v := range p.Vars {
+							if v == string(name) {
+								return types.String(segments[i])
+							}
+						}
+						return types.NewErr(`unknown variable "%s" in pattern "%s"`, name, p.Pattern)
+					}),
+				),
+			),
+			cel.Function("resourceParent",
+				cel.Overload("resourceParent_string", []*cel.Type{cel.StringType}, cel.StringType,
+					cel.UnaryBinding(func(val ref.Val) ref.Val {
+						p, segments, err := match(val)
+						if err != nil {
+							return err
+						}
+						return types.String(p.Parent(segments))
+					}),
+				),
+			),
+		},
+	})
+}
+
+type resourcePattern struct {
+	Type    string
+	Pattern string
+	// Vars contains the variable name of each segment, or an empty string for literal segments
+	Vars     []string
+	segments []string
+}
+
+func newResourcePattern(typ, pattern string) *resourcePattern {
+	p := &resourcePattern{
+		Type:     typ,
+		Pattern:  pattern,
+		segments: strings.Split(pattern, "/"),
+	}
+	for _, segment := range p.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			p.Vars = append(p.Vars, strings.Trim(segment, "{}"))
+		} else {
+			p.Vars = append(p.Vars, "")
+		}
+	}
+	return p
+}
+
+func (p *resourcePattern) Match(segments []string) bool {
+	if len(segments) != len(p.segments) {
+		return false
+	}
+	for i, segment := range segments {
+		if p.Vars[i] != "" {
+			if !segmentRegexp.MatchString(segment) {
+				return false
+			}
+		} else if segment != p.segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *resourcePattern) Parent(segments []string) string {
+	n := len(segments) - 1
+	if n >= 0 && p.Vars[n] != "" {
+		n--
+	}
+	if n <= 0 {
+		return ""
+	}
+	return strings.Join(segments[:n], "/")
 }
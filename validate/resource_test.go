@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/cel-go/cel"
 	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
 )
 
@@ -75,3 +76,88 @@ func TestGenerateResourceTypePatternMapping(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildResourceEnvOption(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Expr      string
+		Value     string
+		WantErr   bool
+		WantFalse bool
+	}{
+		{
+			Name:  "Type",
+			Expr:  `resourceType(name) == "testdata/RefMultiple"`,
+			Value: "multiples/my/refs/ref",
+		},
+		{
+			Name:  "Type (first matching pattern)",
+			Expr:  `resourceType(name) == "testdata/Ref"`,
+			Value: "refs/ref",
+		},
+		{
+			Name:    "Type (no matching pattern)",
+			Expr:    `resourceType(name) == "testdata/Ref"`,
+			Value:   "unknowns/ref",
+			WantErr: true,
+		},
+		{
+			Name:  "Var",
+			Expr:  `resourceVar(name, "mutiple") == "my" && resourceVar(name, "ref") == "ref"`,
+			Value: "multiples/my/refs/ref",
+		},
+		{
+			Name:      "Var mismatch",
+			Expr:      `resourceVar(name, "ref") == "other"`,
+			Value:     "others/refs/ref",
+			WantFalse: true,
+		},
+		{
+			Name:    "Var unknown",
+			Expr:    `resourceVar(name, "unknown") == ""`,
+			Value:   "refs/ref",
+			WantErr: true,
+		},
+		{
+			Name:  "Parent",
+			Expr:  `resourceParent(name) == "multiples/my"`,
+			Value: "multiples/my/refs/ref",
+		},
+		{
+			Name:  "Parent (top level)",
+			Expr:  `resourceParent(name) == ""`,
+			Value: "refs/ref",
+		},
+		{
+			Name:    "Parent without pattern",
+			Expr:    `resourceType(resourceParent(name)) == ""`,
+			Value:   "multiples/my/refs/ref",
+			WantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			env, err := cel.NewEnv(
+				BuildResourceEnvOption(validate.File_testdata_validate_test_proto),
+				cel.Variable("name", cel.StringType),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ast, issues := env.Compile(tt.Expr)
+			if issues != nil && issues.Err() != nil {
+				t.Fatal(issues.Err())
+			}
+			pgr, err := env.Program(ast)
+			if err != nil {
+				t.Fatal(err)
+			}
+			val, _, err := pgr.Eval(map[string]interface{}{"name": tt.Value})
+			if (tt.WantErr && err == nil) || (!tt.WantErr && err != nil) {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			} else if err == nil && val.Value().(bool) == tt.WantFalse {
+				t.Errorf("wantFalse %v, got %v", tt.WantFalse, val.Value())
+			}
+		})
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
 	"github.com/nlachfr/protoc-gen-cel-validate/validate/errors"
 	"google.golang.org/genproto/googleapis/rpc/context/attribute_context"
 	"google.golang.org/protobuf/proto"
@@ -15,26 +18,47 @@ import (
 
 type ServiceRuleValidater interface {
 	Validate(ctx context.Context, attr *attribute_context.AttributeContext, m proto.Message) error
+	Describe() *ServiceDescription
 }
 type serviceRuleValidater struct {
+	desc                  protoreflect.ServiceDescriptor
 	ruleValidater         RuleValidater
 	methodDescs           map[string]protoreflect.MethodDescriptor
 	methodRulesValidaters map[string]MethodRuleValidater
+	costLimit             uint64
+	evaluation
 }
 
 func (v *serviceRuleValidater) Validate(ctx context.Context, attr *attribute_context.AttributeContext, m proto.Message) error {
+	nested := ctx != nil && validationStateFromContext(ctx) != nil
+	start := time.Now()
+	err := v.validate(ctx, attr, m)
+	if !nested {
+		var desc protoreflect.Descriptor = v.desc
+		if attr != nil && attr.Api != nil {
+			if md, ok := v.methodDescs[attr.Api.Operation]; ok {
+				desc = md
+			}
+		}
+		v.observeValidation(ctx, desc, start, err)
+		notifyViolation(ctx, err)
+	}
+	return err
+}
+
+func (v *serviceRuleValidater) validate(ctx context.Context, attr *attribute_context.AttributeContext, m proto.Message) error {
 	if attr == nil || attr.Api == nil {
 		return nil
 	} else {
+		ctx = withValidationState(ctx, v.costLimit)
 		req := map[string]interface{}{
 			"attribute_context": attr,
+			contextVariable:     newContextValue(ctx),
 		}
 		if v.ruleValidater != nil {
 			for _, pgr := range v.ruleValidater.Programs() {
-				if val, _, err := pgr.Program.ContextEval(ctx, req); err != nil {
-					return errors.Wrap(err, m, v.methodDescs[attr.Api.Operation], attr)
-				} else if !types.IsBool(val) || !val.Value().(bool) {
-					return errors.New(m, v.methodDescs[attr.Api.Operation], attr)
+				if err := v.run(ctx, v.desc, pgr, req, m, v.methodDescs[attr.Api.Operation], attr); err != nil {
+					return err
 				}
 			}
 		}
@@ -42,10 +66,8 @@ func (v *serviceRuleValidater) Validate(ctx context.Context, attr *attribute_con
 		if methodValidater, ok := v.methodRulesValidaters[attr.Api.Operation]; ok && methodValidater != nil {
 			if validater := methodValidater.Validater(); validater != nil {
 				for _, pgr := range validater.Programs() {
-					if val, _, err := pgr.Program.ContextEval(ctx, req); err != nil {
-						return errors.Wrap(err, m, v.methodDescs[attr.Api.Operation], attr)
-					} else if !types.IsBool(val) || !val.Value().(bool) {
-						return errors.New(m, v.methodDescs[attr.Api.Operation], attr)
+					if err := v.run(ctx, v.methodDescs[attr.Api.Operation], pgr, req, m, v.methodDescs[attr.Api.Operation], attr); err != nil {
+						return err
 					}
 				}
 			}
@@ -54,6 +76,112 @@ func (v *serviceRuleValidater) Validate(ctx context.Context, attr *attribute_con
 	return nil
 }
 
+// programError returns the error of a program which failed, or was not
+// satisfied
+func programError(err error, pgr *ValidateProgram, m proto.Message, desc protoreflect.Descriptor, attr *attribute_context.AttributeContext) errors.ValidateError {
+	if err != nil {
+		if pgr.redact {
+			err = errors.Redact(err)
+		}
+		return errors.WithProgram(errors.Wrap(err, m, desc, attr), pgr.Id, pgr.Expr)
+	}
+	return errors.WithProgram(errors.New(m, desc, attr), pgr.Id, pgr.Expr)
+}
+
+// evaluation holds the runtime settings of the program evaluations
+type evaluation struct {
+	// sensitive reports the fields whose values are removed from the
+	// messages of the errors
+	sensitive func(fd protoreflect.FieldDescriptor) bool
+	disabled  *programSwitch
+	shadow    *atomic.Bool
+	observer  Observer
+	explain   bool
+	coverage  *Coverage
+}
+
+// run evaluates the program on behalf of the element desc, unless it is
+// disabled, and returns the error failing the validation, if any
+func (e *evaluation) run(ctx context.Context, desc protoreflect.Descriptor, pgr *ValidateProgram, vars map[string]interface{}, m proto.Message, errDesc protoreflect.Descriptor, attr *attribute_context.AttributeContext) error {
+	start := time.Now()
+	if e.skip(ctx, desc, errDesc, pgr) {
+		e.observeProgram(ctx, desc, pgr, start, OutcomeSkipped, nil)
+		return nil
+	}
+	if ok, err := evalProgram(ctx, pgr, vars); err != nil || !ok {
+		vErr := programError(err, pgr, e.redact(m), errDesc, attr)
+		if e.explain && vErr.GetKind() == errors.KindViolation && vErr.GetProgramId() == pgr.Id && vErr.GetExpr() == pgr.Expr {
+			errors.WithExplanation(vErr, pgr.explain(ctx, vars))
+		}
+		e.observeProgram(ctx, desc, pgr, start, OutcomeFailed, vErr)
+		return e.enforce(ctx, pgr.Mode, vErr)
+	}
+	e.observeProgram(ctx, desc, pgr, start, OutcomePassed, nil)
+	return nil
+}
+
+// enforce returns the error, unless the program only audits or the shadow mode
+// is enabled: the error is then given to the audit listeners
+func (e *evaluation) enforce(ctx context.Context, mode Rule_Program_Mode, err errors.ValidateError) error {
+	if mode == Rule_Program_AUDIT || (e.shadow != nil && e.shadow.Load()) {
+		notifyAudit(ctx, err)
+		return nil
+	}
+	return err
+}
+
+// skip reports whether the program is disabled at runtime, globally or by the
+// configuration, notifying the skip listeners. The programs of a service are
+// also matched against the called method.
+func (e *evaluation) skip(ctx context.Context, desc, method protoreflect.Descriptor, pgr *ValidateProgram) bool {
+	var element protoreflect.FullName
+	if desc != nil {
+		element = desc.FullName()
+	}
+	elements := []protoreflect.FullName{element}
+	if method != nil && method != desc {
+		elements = append(elements, method.FullName())
+	}
+	for _, element := range elements {
+		if disabledPrograms.Load().disabled(element, pgr.Id) || e.disabled.disabled(element, pgr.Id) {
+			notifySkip(ctx, desc, pgr)
+			return true
+		}
+	}
+	return false
+}
+
+// evalProgram evaluates the program, unless the context is already done or the
+// cost limit of the validation is exceeded. The cost of the program is limited
+// to the budget left to the validation.
+func evalProgram(ctx context.Context, pgr *ValidateProgram, vars map[string]interface{}) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	program, state := pgr.Program, validationStateFromContext(ctx)
+	if state != nil {
+		if remaining, ok := state.remaining(); ok {
+			var err error
+			if program, err = pgr.clamped(remaining); err != nil {
+				return false, err
+			}
+		}
+	}
+	val, details, err := program.ContextEval(ctx, vars)
+	if err != nil {
+		return false, err
+	}
+	if details != nil && details.ActualCost() != nil && state != nil {
+		if err := state.addCost(*details.ActualCost()); err != nil {
+			return false, err
+		}
+	}
+	if e, ok := val.Value().(error); ok {
+		return false, e
+	}
+	return types.IsBool(val) && val.Value().(bool), nil
+}
+
 type MethodRuleValidater interface {
 	Validater() RuleValidater
 }
@@ -66,21 +194,45 @@ func (v *methodRuleValidater) Validater() RuleValidater { return v.validater }
 type MessageRuleValidater interface {
 	ValidateWithMask(ctx context.Context, m proto.Message, fm *fieldmaskpb.FieldMask) error
 	HasValidaters() bool
+	Describe() *MessageDescription
+	Constants() []ref.Val
 }
 
 type messageRuleValidater struct {
+	desc                 protoreflect.MessageDescriptor
 	ruleValidater        RuleValidater
 	fieldRulesValidaters map[string]FieldRuleValidater
+	costLimit            uint64
+	nestedValidater      func(desc protoreflect.MessageDescriptor) (MessageRuleValidater, error)
+	evaluation
 }
 
 func (v *messageRuleValidater) ValidateWithMask(ctx context.Context, m proto.Message, fm *fieldmaskpb.FieldMask) error {
+	nested := ctx != nil && validationStateFromContext(ctx) != nil
+	start := time.Now()
+	err := v.validateWithMask(ctx, m, fm)
+	if !nested {
+		v.observeValidation(ctx, m.ProtoReflect().Descriptor(), start, err)
+		notifyViolation(ctx, err)
+	}
+	return err
+}
+
+func (v *messageRuleValidater) validateWithMask(ctx context.Context, m proto.Message, fm *fieldmaskpb.FieldMask) error {
 	if v.fieldRulesValidaters == nil && v.ruleValidater == nil {
 		return fmt.Errorf("validation failed")
 	}
-	vars := map[string]interface{}{}
+	ctx = withValidationState(ctx, v.costLimit)
+	vars := map[string]interface{}{
+		contextVariable: newContextValue(ctx),
+	}
 	for i := 0; i < m.ProtoReflect().Descriptor().Fields().Len(); i++ {
 		field := m.ProtoReflect().Descriptor().Fields().Get(i)
-		vars[field.TextName()] = m.ProtoReflect().Get(field)
+		if field.Message() != nil && !field.IsList() && !field.IsMap() {
+			vars[field.TextName()] = m.ProtoReflect().Get(field).Message().Interface()
+		} else {
+			vars[field.TextName()] = m.ProtoReflect().Get(field)
+		}
 	}
 	pathsMap := map[string][]string{}
 	mdesc := m.ProtoReflect().Descriptor()
@@ -92,10 +244,8 @@ func (v *messageRuleValidater) ValidateWithMask(ctx context.Context, m proto.Mes
 	} else if len(fm.Paths) == 1 && fm.Paths[0] == "*" {
 		if v.ruleValidater != nil {
 			for _, p := range v.ruleValidater.Programs() {
-				if val, _, err := p.Program.ContextEval(ctx, vars); err != nil {
-					return errors.Wrap(err, m, mdesc, nil)
-				} else if !types.IsBool(val) || !val.Value().(bool) {
-					return errors.New(m, mdesc, nil)
+				if err := v.run(ctx, mdesc, p, vars, m, mdesc, nil); err != nil {
+					return err
 				}
 			}
 		}
@@ -128,15 +278,15 @@ func (v *messageRuleValidater) ValidateWithMask(ctx context.Context, m proto.Mes
 						if !IsDefaultValue(m, fdesc) {
 							if fieldValidater.Validater() != nil {
 								for _, p := range fieldValidater.Validater().Programs() {
-									if val, _, err := p.Program.ContextEval(ctx, vars); err != nil {
-										return errors.Wrap(err, m, fdesc, nil)
-									} else if !types.IsBool(val) || !val.Value().(bool) {
-										return errors.New(m, fdesc, nil)
+									if err := v.run(ctx, fdesc, p, vars, m, fdesc, nil); err != nil {
+										return err
 									}
 								}
 							}
 						} else if fieldValidater.IsRequired() {
-							return errors.New(m, fdesc, nil)
+							if err := v.enforce(ctx, Rule_Program_ENFORCE, errors.New(v.redact(m), fdesc, nil)); err != nil {
+								return err
+							}
 						}
 					}
 				} else if paths[j] != "*" {
@@ -144,9 +294,18 @@ func (v *messageRuleValidater) ValidateWithMask(ctx context.Context, m proto.Mes
 				}
 			}
 			if len(subs) > 0 && fdesc.Kind() == protoreflect.MessageKind {
-				if v, ok := m.ProtoReflect().Get(fdesc).Message().Interface().(Validater); ok {
-					if err := v.ValidateWithMask(ctx, &fieldmaskpb.FieldMask{Paths: subs}); err != nil {
-						return errors.Wrap(err, m, fdesc, nil)
+				nested := m.ProtoReflect().Get(fdesc).Message().Interface()
+				if nv, ok := nested.(Validater); ok {
+					if err := nv.ValidateWithMask(ctx, &fieldmaskpb.FieldMask{Paths: subs}); err != nil {
+						return errors.Wrap(err, v.redact(m), fdesc, nil)
+					}
+				} else if v.nestedValidater != nil {
+					nv, err := v.nestedValidater(fdesc.Message())
+					if err != nil {
+						return errors.Wrap(err, v.redact(m), fdesc, nil)
+					}
+					if err := nv.ValidateWithMask(ctx, nested, &fieldmaskpb.FieldMask{Paths: subs}); err != nil {
+						return errors.Wrap(err, v.redact(m), fdesc, nil)
 					}
 				}
 			}
@@ -162,11 +321,13 @@ func (v *messageRuleValidater) HasValidaters() bool {
 type FieldRuleValidater interface {
 	Validater() RuleValidater
 	IsRequired() bool
+	RequiredSource() Source
 }
 
 type fieldRuleValidater struct {
-	validater RuleValidater
-	required  bool
+	validater      RuleValidater
+	required       bool
+	requiredSource Source
 }
 
 func (v *fieldRuleValidater) Validater() RuleValidater {
@@ -175,3 +336,6 @@ func (v *fieldRuleValidater) Validater() RuleValidater {
 func (v *fieldRuleValidater) IsRequired() bool {
 	return v.required
 }
+func (v *fieldRuleValidater) RequiredSource() Source {
+	return v.requiredSource
+}
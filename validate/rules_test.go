@@ -2,9 +2,12 @@ package validate
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/google/cel-go/cel"
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate/errors"
 	"google.golang.org/genproto/googleapis/rpc/context/attribute_context"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -206,9 +209,109 @@ func TestMessageRuleValidater(t *testing.T) {
 		Validater     func() MessageRuleValidater
 		Request       proto.Message
 		FieldMask     *fieldmaskpb.FieldMask
+		Context       context.Context
 		HasValidaters bool
 		WantErr       bool
 	}{
+		{
+			Name: "Cancelled context",
+			Validater: func() MessageRuleValidater {
+				desc := (&timestamppb.Timestamp{}).ProtoReflect().Descriptor()
+				lib := &Library{
+					EnvOpts: []cel.EnvOption{
+						cel.DeclareContextProto(desc),
+						BuildEnvOption(nil),
+					},
+				}
+				rv, err := BuildRuleValidater(&Rule{
+					Programs: []*Rule_Program{{Expr: `true`}},
+				}, cel.Lib(lib))
+				if err != nil {
+					panic(err)
+				}
+				return &messageRuleValidater{ruleValidater: rv}
+			},
+			Context: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			}(),
+			HasValidaters: true,
+			Request:       &timestamppb.Timestamp{Nanos: 5},
+			FieldMask:     &fieldmaskpb.FieldMask{Paths: []string{"*"}},
+			WantErr:       true,
+		},
+		{
+			Name: "Program cost limit exceeded",
+			Validater: func() MessageRuleValidater {
+				desc := (&timestamppb.Timestamp{}).ProtoReflect().Descriptor()
+				lib := &Library{
+					EnvOpts: []cel.EnvOption{
+						cel.DeclareContextProto(desc),
+						BuildEnvOption(nil),
+					},
+				}
+				rv, err := BuildRuleValidater(&Rule{
+					Options:  &Options{CostLimit: 2},
+					Programs: []*Rule_Program{{Expr: `[1, 2, 3, 4].all(x, x < nanos)`}},
+				}, cel.Lib(lib))
+				if err != nil {
+					panic(err)
+				}
+				return &messageRuleValidater{ruleValidater: rv}
+			},
+			HasValidaters: true,
+			Request:       &timestamppb.Timestamp{Nanos: 5},
+			FieldMask:     &fieldmaskpb.FieldMask{Paths: []string{"*"}},
+			WantErr:       true,
+		},
+		{
+			Name: "Validation cost limit exceeded",
+			Validater: func() MessageRuleValidater {
+				desc := (&timestamppb.Timestamp{}).ProtoReflect().Descriptor()
+				lib := &Library{
+					EnvOpts: []cel.EnvOption{
+						cel.DeclareContextProto(desc),
+						BuildEnvOption(nil),
+					},
+				}
+				rv, err := BuildRuleValidater(&Rule{
+					Options:  &Options{CostLimit: 100},
+					Programs: []*Rule_Program{{Expr: `nanos > 1`}, {Expr: `nanos > 2`}, {Expr: `nanos > 3`}},
+				}, cel.Lib(lib))
+				if err != nil {
+					panic(err)
+				}
+				return &messageRuleValidater{ruleValidater: rv, costLimit: 5}
+			},
+			HasValidaters: true,
+			Request:       &timestamppb.Timestamp{Nanos: 5},
+			FieldMask:     &fieldmaskpb.FieldMask{Paths: []string{"*"}},
+			WantErr:       true,
+		},
+		{
+			Name: "OK (validation cost limit)",
+			Validater: func() MessageRuleValidater {
+				desc := (&timestamppb.Timestamp{}).ProtoReflect().Descriptor()
+				lib := &Library{
+					EnvOpts: []cel.EnvOption{
+						cel.DeclareContextProto(desc),
+						BuildEnvOption(nil),
+					},
+				}
+				rv, err := BuildRuleValidater(&Rule{
+					Options:  &Options{CostLimit: 100},
+					Programs: []*Rule_Program{{Expr: `nanos > 1`}, {Expr: `nanos > 2`}},
+				}, cel.Lib(lib))
+				if err != nil {
+					panic(err)
+				}
+				return &messageRuleValidater{ruleValidater: rv, costLimit: 100}
+			},
+			HasValidaters: true,
+			Request:       &timestamppb.Timestamp{Nanos: 5},
+			FieldMask:     &fieldmaskpb.FieldMask{Paths: []string{"*"}},
+		},
 		{
 			Name: "Field rule failure",
 			Validater: func() MessageRuleValidater {
@@ -402,10 +505,142 @@ func TestMessageRuleValidater(t *testing.T) {
 			if tt.HasValidaters != v.HasValidaters() {
 				t.Errorf("want %v, got %v", tt.HasValidaters, v.HasValidaters())
 			}
-			err := v.ValidateWithMask(context.Background(), tt.Request, tt.FieldMask)
+			ctx := tt.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			err := v.ValidateWithMask(ctx, tt.Request, tt.FieldMask)
 			if (tt.WantErr && err == nil) || (!tt.WantErr && err != nil) {
 				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
 			}
 		})
 	}
 }
+
+func TestAuditMode(t *testing.T) {
+	audit := func(mode Rule_Program_Mode) *Configuration {
+		return &Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+			"testdata.validate.MessageOverride": {Rule: &Rule{Programs: []*Rule_Program{{Id: "audit", Expr: `name.startsWith("a")`, Mode: mode}}}},
+		}}}
+	}
+	tests := []struct {
+		Name        string
+		Config      *Configuration
+		Shadow      bool
+		Message     proto.Message
+		WantErr     bool
+		WantAudited []string
+	}{
+		{
+			Name:    "Enforced",
+			Config:  audit(Rule_Program_ENFORCE),
+			Message: &validate.MessageOverride{Name: "name"},
+			WantErr: true,
+		},
+		{
+			Name:        "Audited",
+			Config:      audit(Rule_Program_AUDIT),
+			Message:     &validate.MessageOverride{Name: "name"},
+			WantAudited: []string{"audit"},
+		},
+		{
+			Name:        "Audited and enforced",
+			Config:      audit(Rule_Program_AUDIT),
+			Message:     &validate.MessageOverride{},
+			WantErr:     true,
+			WantAudited: []string{"audit"},
+		},
+		{
+			Name:        "Shadow",
+			Config:      audit(Rule_Program_ENFORCE),
+			Shadow:      true,
+			Message:     &validate.MessageOverride{},
+			WantAudited: []string{"audit", "override"},
+		},
+		{
+			Name:        "Shadow required",
+			Config:      &Configuration{},
+			Shadow:      true,
+			Message:     &validate.FieldRequired{},
+			WantAudited: []string{""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			desc := tt.Message.ProtoReflect().Descriptor()
+			m := newManager(desc.ParentFile(), WithConfiguration(tt.Config))
+			m.SetShadowMode(tt.Shadow)
+			v, err := m.GetMessageRuleValidater(desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var audited []string
+			remove := AddAuditListener(func(ctx context.Context, err errors.ValidateError) {
+				audited = append(audited, err.GetProgramId())
+			})
+			defer remove()
+			if err := v.ValidateWithMask(context.Background(), tt.Message, &fieldmaskpb.FieldMask{Paths: []string{"*"}}); (err != nil) != tt.WantErr {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			}
+			if !reflect.DeepEqual(audited, tt.WantAudited) {
+				t.Errorf("want audited %v, got %v", tt.WantAudited, audited)
+			}
+		})
+	}
+}
+
+func TestValidationCostLimit(t *testing.T) {
+	expr := `[1, 2, 3, 4, 5, 6, 7, 8, 9, 10].all(x, name.size() < 100 + x)`
+	tests := []struct {
+		Name      string
+		CostLimit uint64
+		Programs  []*Rule_Program
+		WantKind  errors.Kind
+		WantErr   bool
+	}{
+		{
+			Name:      "Within the budget",
+			CostLimit: 1000,
+			Programs:  []*Rule_Program{{Id: "first", Expr: expr}, {Id: "second", Expr: expr}},
+		},
+		{
+			Name:      "Program exceeding the budget left",
+			CostLimit: 120,
+			Programs:  []*Rule_Program{{Id: "first", Expr: expr}, {Id: "second", Expr: expr}},
+			WantKind:  errors.KindCostLimitExceeded,
+			WantErr:   true,
+		},
+		{
+			Name:      "Program exceeding the whole budget",
+			CostLimit: 40,
+			Programs:  []*Rule_Program{{Id: "first", Expr: expr}},
+			WantKind:  errors.KindCostLimitExceeded,
+			WantErr:   true,
+		},
+	}
+	desc := validate.File_testdata_validate_message_proto.Messages().ByName("MessageExpr")
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			m, err := NewManager(desc.ParentFile(), WithCostLimit(tt.CostLimit), WithConfiguration(&Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+				string(desc.FullName()): {FieldRules: map[string]*FieldRule{"name": {Rule: &Rule{Programs: tt.Programs}}}},
+			}}}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			v, err := m.GetMessageRuleValidater(desc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ctx := withValidationState(context.Background(), tt.CostLimit)
+			err = v.ValidateWithMask(ctx, &validate.MessageExpr{Name: "name"}, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+			if (err != nil) != tt.WantErr {
+				t.Fatalf("wantErr %v, got %v", tt.WantErr, err)
+			} else if vErr, ok := err.(errors.ValidateError); err != nil && (!ok || vErr.GetKind() != tt.WantKind) {
+				t.Errorf("want kind %v, got %v", tt.WantKind, err)
+			}
+			if cost := validationStateFromContext(ctx).cost; cost > tt.CostLimit {
+				t.Errorf("want cost within %d, got %d", tt.CostLimit, cost)
+			}
+		})
+	}
+}
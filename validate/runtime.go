@@ -0,0 +1,255 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+var defaultRuntime = NewRuntime()
+
+// DefaultRuntime returns the Runtime used by Message when called without
+// options
+func DefaultRuntime() *Runtime {
+	return defaultRuntime
+}
+
+// Message validates any message, including dynamicpb ones, without relying on
+// generated code. Options are applied to a new Runtime on every call, so that
+// repeated validations with options should use their own Runtime instead.
+func Message(ctx context.Context, m proto.Message, opts ...ManagerOption) error {
+	if len(opts) > 0 {
+		return NewRuntime(opts...).Validate(ctx, m)
+	}
+	return defaultRuntime.Validate(ctx, m)
+}
+
+// Runtime validates messages without generated code, by lazily building a
+// Manager for every file of the validated messages
+type Runtime struct {
+	files    *protoregistry.Files
+	types    *protoregistry.Types
+	opts     []ManagerOption
+	mu       sync.Mutex
+	managers map[string]*Manager
+}
+
+// NewRuntime returns a Runtime resolving descriptors from protoregistry.GlobalFiles
+func NewRuntime(opts ...ManagerOption) *Runtime {
+	return newRuntime(protoregistry.GlobalFiles, protoregistry.GlobalTypes, opts...)
+}
+
+// NewRuntimeFromFileDescriptorSet returns a Runtime resolving descriptors from
+// the set, such as the output of protoc -o. Files must be ordered with their
+// dependencies first, which can be omitted when registered in
+// protoregistry.GlobalFiles.
+func NewRuntimeFromFileDescriptorSet(set *descriptorpb.FileDescriptorSet, opts ...ManagerOption) (*Runtime, error) {
+	files := &protoregistry.Files{}
+	types := &protoregistry.Types{}
+	resolver := &fallbackFilesResolver{files: files}
+	for _, fdp := range set.GetFile() {
+		fd, err := protodesc.NewFile(fdp, resolver)
+		if err != nil {
+			return nil, fmt.Errorf("file %s error: %w", fdp.GetName(), err)
+		} else if err = files.RegisterFile(fd); err != nil {
+			return nil, fmt.Errorf("file %s error: %w", fdp.GetName(), err)
+		} else if err = registerTypes(types, fd.Messages(), fd.Enums(), fd.Extensions()); err != nil {
+			return nil, fmt.Errorf("file %s error: %w", fdp.GetName(), err)
+		}
+	}
+	return newRuntime(files, types, opts...), nil
+}
+
+func newRuntime(files *protoregistry.Files, types *protoregistry.Types, opts ...ManagerOption) *Runtime {
+	return &Runtime{
+		files:    files,
+		types:    types,
+		opts:     append([]ManagerOption{WithFallbackOverloads()}, opts...),
+		managers: map[string]*Manager{},
+	}
+}
+
+func (r *Runtime) Validate(ctx context.Context, m proto.Message) error {
+	return r.ValidateWithMask(ctx, m, &fieldmaskpb.FieldMask{Paths: []string{"*"}})
+}
+
+func (r *Runtime) ValidateWithMask(ctx context.Context, m proto.Message, fm *fieldmaskpb.FieldMask) error {
+	v, err := r.GetMessageRuleValidater(m.ProtoReflect().Descriptor())
+	if err != nil {
+		return err
+	}
+	return v.ValidateWithMask(ctx, m, fm)
+}
+
+// NewMessage returns an empty message of the given type
+func (r *Runtime) NewMessage(name string) (proto.Message, error) {
+	mt, err := r.types.FindMessageByName(protoreflect.FullName(name))
+	if err == protoregistry.NotFound {
+		mt, err = protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(name))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("message %s error: %w", name, err)
+	}
+	return mt.New().Interface(), nil
+}
+
+// ValidateBytes unmarshals the wire encoded message of the given type, and
+// validates it
+func (r *Runtime) ValidateBytes(ctx context.Context, name string, b []byte) error {
+	m, err := r.NewMessage(name)
+	if err != nil {
+		return err
+	}
+	if err = (proto.UnmarshalOptions{Resolver: r.resolver()}).Unmarshal(b, m); err != nil {
+		return fmt.Errorf("unmarshal error: %w", err)
+	}
+	return r.Validate(ctx, m)
+}
+
+// ValidateJSON unmarshals the JSON encoded message of the given type, and
+// validates it
+func (r *Runtime) ValidateJSON(ctx context.Context, name string, b []byte) error {
+	m, err := r.NewMessage(name)
+	if err != nil {
+		return err
+	}
+	if err = (protojson.UnmarshalOptions{Resolver: r.resolver()}).Unmarshal(b, m); err != nil {
+		return fmt.Errorf("unmarshal error: %w", err)
+	}
+	return r.Validate(ctx, m)
+}
+
+// TypeResolver resolves the message and extension types of a Runtime, as
+// expected when unmarshaling messages
+type TypeResolver interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+}
+
+func (r *Runtime) TypeResolver() TypeResolver {
+	return r.resolver()
+}
+
+func (r *Runtime) resolver() *fallbackTypesResolver {
+	return &fallbackTypesResolver{types: r.types}
+}
+
+// Files returns the files the descriptors are resolved from
+func (r *Runtime) Files() *protoregistry.Files {
+	return r.files
+}
+
+func (r *Runtime) GetServiceRuleValidater(desc protoreflect.ServiceDescriptor) (ServiceRuleValidater, error) {
+	if d, err := r.files.FindDescriptorByName(desc.FullName()); err == nil {
+		if sd, ok := d.(protoreflect.ServiceDescriptor); ok {
+			desc = sd
+		}
+	}
+	return r.manager(desc.ParentFile()).GetServiceRuleValidater(desc)
+}
+
+func (r *Runtime) GetMessageRuleValidater(desc protoreflect.MessageDescriptor) (MessageRuleValidater, error) {
+	if d, err := r.files.FindDescriptorByName(desc.FullName()); err == nil {
+		if md, ok := d.(protoreflect.MessageDescriptor); ok {
+			desc = md
+		}
+	}
+	return r.manager(desc.ParentFile()).GetMessageRuleValidater(desc)
+}
+
+func (r *Runtime) manager(file protoreflect.FileDescriptor) *Manager {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m, ok := r.managers[file.Path()]
+	if !ok {
+		m = newManager(file, r.opts...)
+		m.b.nested = r.GetMessageRuleValidater
+		r.managers[file.Path()] = m
+	}
+	return m
+}
+
+func registerTypes(types *protoregistry.Types, messages protoreflect.MessageDescriptors, enums protoreflect.EnumDescriptors, extensions protoreflect.ExtensionDescriptors) error {
+	for i := 0; i < enums.Len(); i++ {
+		if err := types.RegisterEnum(dynamicpb.NewEnumType(enums.Get(i))); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < extensions.Len(); i++ {
+		if err := types.RegisterExtension(dynamicpb.NewExtensionType(extensions.Get(i))); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		if err := types.RegisterMessage(dynamicpb.NewMessageType(md)); err != nil {
+			return err
+		} else if err = registerTypes(types, md.Messages(), md.Enums(), md.Extensions()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fallbackFilesResolver resolves the files missing from the set with
+// protoregistry.GlobalFiles
+type fallbackFilesResolver struct {
+	files *protoregistry.Files
+}
+
+func (r *fallbackFilesResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := r.files.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r *fallbackFilesResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := r.files.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+// fallbackTypesResolver resolves the types missing from the set with
+// protoregistry.GlobalTypes
+type fallbackTypesResolver struct {
+	types *protoregistry.Types
+}
+
+func (r *fallbackTypesResolver) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	if mt, err := r.types.FindMessageByName(name); err == nil {
+		return mt, nil
+	}
+	return protoregistry.GlobalTypes.FindMessageByName(name)
+}
+
+func (r *fallbackTypesResolver) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	if mt, err := r.types.FindMessageByURL(url); err == nil {
+		return mt, nil
+	}
+	return protoregistry.GlobalTypes.FindMessageByURL(url)
+}
+
+func (r *fallbackTypesResolver) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	if xt, err := r.types.FindExtensionByName(field); err == nil {
+		return xt, nil
+	}
+	return protoregistry.GlobalTypes.FindExtensionByName(field)
+}
+
+func (r *fallbackTypesResolver) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	if xt, err := r.types.FindExtensionByNumber(message, field); err == nil {
+		return xt, nil
+	}
+	return protoregistry.GlobalTypes.FindExtensionByNumber(message, field)
+}
@@ -0,0 +1,212 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestRuntime(t *testing.T) {
+	dynamicMessage := func(m proto.Message) proto.Message {
+		dm := dynamicpb.NewMessage(m.ProtoReflect().Descriptor())
+		raw, err := proto.Marshal(m)
+		if err != nil {
+			panic(err)
+		}
+		if err = proto.Unmarshal(raw, dm); err != nil {
+			panic(err)
+		}
+		return dm
+	}
+	nestedConfiguration := &Configuration{
+		Rule: &FileRule{
+			MessageRules: map[string]*MessageRule{
+				"testdata.Nested": {
+					FieldRules: map[string]*FieldRule{
+						"name": {Rule: &Rule{Programs: []*Rule_Program{{Expr: `name == "ok"`}}}},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		Name      string
+		Message   proto.Message
+		FieldMask *fieldmaskpb.FieldMask
+		Opts      []ManagerOption
+		WantErr   bool
+	}{
+		{
+			Name:    "Message rule failure",
+			Message: &validate.MessageExpr{},
+			WantErr: true,
+		},
+		{
+			Name:    "Message rule failure (dynamic)",
+			Message: dynamicMessage(&validate.MessageExpr{}),
+			WantErr: true,
+		},
+		{
+			Name:    "Nested field failure",
+			Message: &validate.MessageNestedExpr{MessageExpr: &validate.MessageExpr{}},
+			WantErr: true,
+		},
+		{
+			Name:      "Nested field with mask failure (dynamic)",
+			Message:   dynamicMessage(&validate.TestRpcRequest{Nested: &validate.Nested{Name: "ko"}}),
+			FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"nested.name"}},
+			Opts:      []ManagerOption{WithConfiguration(nestedConfiguration)},
+			WantErr:   true,
+		},
+		{
+			Name:    "Nested field failure with options (dynamic)",
+			Message: dynamicMessage(&validate.TestRpcRequest{Nested: &validate.Nested{Name: "ko"}}),
+			Opts:    []ManagerOption{WithConfiguration(nestedConfiguration)},
+			WantErr: true,
+		},
+		{
+			Name:    "OK",
+			Message: &validate.MessageExpr{Name: "name"},
+		},
+		{
+			Name:    "OK (dynamic)",
+			Message: dynamicMessage(&validate.MessageNestedExpr{MessageExpr: &validate.MessageExpr{Name: "name"}}),
+		},
+		{
+			Name:      "OK (nested field with mask)",
+			Message:   dynamicMessage(&validate.TestRpcRequest{Nested: &validate.Nested{Name: "ok"}}),
+			FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"nested.name"}},
+			Opts:      []ManagerOption{WithConfiguration(nestedConfiguration)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			var err error
+			if tt.FieldMask != nil {
+				err = NewRuntime(tt.Opts...).ValidateWithMask(context.Background(), tt.Message, tt.FieldMask)
+			} else {
+				err = Message(context.Background(), tt.Message, tt.Opts...)
+			}
+			if (tt.WantErr && err == nil) || (!tt.WantErr && err != nil) {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			}
+		})
+	}
+}
+
+func TestRuntimeNestedValidaterCached(t *testing.T) {
+	r := NewRuntime(WithConfiguration(&Configuration{Rule: &FileRule{MessageRules: map[string]*MessageRule{
+		"testdata.Nested": {FieldRules: map[string]*FieldRule{
+			"name": {Rule: &Rule{Programs: []*Rule_Program{{Expr: `name == "ok"`}}}},
+		}},
+	}}}))
+	m := dynamicpb.NewMessage((&validate.TestRpcRequest{}).ProtoReflect().Descriptor())
+	nested := dynamicpb.NewMessage((&validate.Nested{}).ProtoReflect().Descriptor())
+	nested.Set(nested.Descriptor().Fields().ByName("name"), protoreflect.ValueOfString("ko"))
+	m.Set(m.Descriptor().Fields().ByName("nested"), protoreflect.ValueOfMessage(nested))
+	fm := &fieldmaskpb.FieldMask{Paths: []string{"nested.name"}}
+	if err := r.ValidateWithMask(context.Background(), m, fm); err == nil {
+		t.Fatalf("want error")
+	}
+	manager := r.manager(validate.File_testdata_validate_test_proto)
+	manager.mu.RLock()
+	cached := manager.messageValidaters["testdata.Nested"].rv
+	manager.mu.RUnlock()
+	if cached == nil {
+		t.Fatalf("want the nested validater to be cached")
+	}
+	if err := r.ValidateWithMask(context.Background(), m, fm); err == nil {
+		t.Fatalf("want error")
+	}
+	if v, err := r.GetMessageRuleValidater(validate.File_testdata_validate_test_proto.Messages().ByName("Nested")); err != nil || v != cached {
+		t.Errorf("want the cached nested validater, got %v (%v)", v, err)
+	}
+}
+
+func TestRuntimeFromFileDescriptorSet(t *testing.T) {
+	set := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(validate.File_testdata_validate_message_proto),
+		},
+	}
+	raw, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// options are kept as unknown fields, as when read from a schema registry
+	set = &descriptorpb.FileDescriptorSet{}
+	if err = (proto.UnmarshalOptions{Resolver: &protoregistry.Types{}}).Unmarshal(raw, set); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewRuntimeFromFileDescriptorSet(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawMessage, err := proto.Marshal(&validate.MessageNestedExpr{MessageExpr: &validate.MessageExpr{Name: "name"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		Name    string
+		Message string
+		JSON    string
+		Bytes   []byte
+		WantErr bool
+	}{
+		{
+			Name:    "Unknown message",
+			Message: "testdata.validate.Unknown",
+			JSON:    `{}`,
+			WantErr: true,
+		},
+		{
+			Name:    "Invalid JSON",
+			Message: "testdata.validate.MessageExpr",
+			JSON:    `{"unknown": ""}`,
+			WantErr: true,
+		},
+		{
+			Name:    "Message rule failure",
+			Message: "testdata.validate.MessageExpr",
+			JSON:    `{"name": ""}`,
+			WantErr: true,
+		},
+		{
+			Name:    "Nested message rule failure",
+			Message: "testdata.validate.MessageNestedExpr",
+			JSON:    `{"messageExpr": {}}`,
+			WantErr: true,
+		},
+		{
+			Name:    "OK",
+			Message: "testdata.validate.MessageExpr",
+			JSON:    `{"name": "name"}`,
+		},
+		{
+			Name:    "OK (bytes)",
+			Message: "testdata.validate.MessageNestedExpr",
+			Bytes:   rawMessage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			var err error
+			if tt.Bytes != nil {
+				err = r.ValidateBytes(context.Background(), tt.Message, tt.Bytes)
+			} else {
+				err = r.ValidateJSON(context.Background(), tt.Message, []byte(tt.JSON))
+			}
+			if (tt.WantErr && err == nil) || (!tt.WantErr && err != nil) {
+				t.Errorf("wantErr %v, got %v", tt.WantErr, err)
+			}
+		})
+	}
+}
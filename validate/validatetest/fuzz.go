@@ -0,0 +1,373 @@
+package validatetest
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxMutationDepth is the depth of the nested messages mutated by Mutate
+const maxMutationDepth = 4
+
+// maxMutatedSize is the size of the strings and bytes built from the integer
+// constants of the rules
+const maxMutatedSize = 4096
+
+// Fuzz runs a fuzz target mutating copies of the seed with Mutate, and checks
+// their validation with CheckValidation
+func Fuzz(f *testing.F, seed proto.Message, opts ...Option) {
+	f.Helper()
+	constants, err := newOptions(opts).constants(seed.ProtoReflect().Descriptor())
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add([]byte{})
+	for i := 0; i < 8; i++ {
+		f.Add(bytes.Repeat([]byte{byte(i * 37)}, 16))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m := proto.Clone(seed)
+		(&mutator{data: data, constants: constants}).mutate(m.ProtoReflect(), 0)
+		CheckValidation(t, m, opts...)
+	})
+}
+
+// Mutate mutates the message, deterministically from the data, setting its
+// fields, nested ones included, to values built from the data and from the
+// constants of the rules of the messages
+func Mutate(m proto.Message, data []byte, opts ...Option) error {
+	constants, err := newOptions(opts).constants(m.ProtoReflect().Descriptor())
+	if err != nil {
+		return err
+	}
+	(&mutator{data: data, constants: constants}).mutate(m.ProtoReflect(), 0)
+	return nil
+}
+
+// CheckValidation reports an error if the validation of the message panics,
+// is not deterministic, or if its generated Validate method differs from its
+// validation by the runtime if set, or by the default runtime otherwise
+func CheckValidation(t testing.TB, m proto.Message, opts ...Option) (ok bool) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("validation of %s panicked: %v\n%s", format(m), r, debug.Stack())
+			ok = false
+		}
+	}()
+	first, second := Validate(m, opts...), Validate(m, opts...)
+	if errorString(first) != errorString(second) {
+		t.Errorf("validation of %s is not deterministic: got %v, then %v", format(m), first, second)
+		return false
+	}
+	if v, ok := m.(interface {
+		Validate(ctx context.Context) error
+	}); ok {
+		o := newOptions(opts)
+		r := o.runtime
+		if r == nil {
+			r = validate.DefaultRuntime()
+		}
+		generated, runtime := v.Validate(o.ctx), r.Validate(o.ctx, m)
+		if errorString(generated) != errorString(runtime) {
+			t.Errorf("generated validation of %s differs from the runtime: got %v, then %v", format(m), generated, runtime)
+			return false
+		}
+	}
+	return true
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func format(m proto.Message) string {
+	return fmt.Sprintf("%s{%s}", m.ProtoReflect().Descriptor().FullName(), prototext.MarshalOptions{}.Format(m))
+}
+
+// validater returns the validater of the message, from the runtime if set,
+// from the registered manager of its file, or from the default runtime otherwise
+func (o *options) validater(md protoreflect.MessageDescriptor) (validate.MessageRuleValidater, error) {
+	if o.runtime != nil {
+		return o.runtime.GetMessageRuleValidater(md)
+	}
+	for _, m := range validate.Managers() {
+		if m.File().Path() == md.ParentFile().Path() {
+			return m.GetMessageRuleValidater(md)
+		}
+	}
+	return validate.DefaultRuntime().GetMessageRuleValidater(md)
+}
+
+// constants returns the constants of the rules of the message, and of the
+// messages of its fields
+func (o *options) constants(md protoreflect.MessageDescriptor) ([]ref.Val, error) {
+	constants := []ref.Val{}
+	visited := map[protoreflect.FullName]bool{}
+	var visit func(md protoreflect.MessageDescriptor) error
+	visit = func(md protoreflect.MessageDescriptor) error {
+		if visited[md.FullName()] {
+			return nil
+		}
+		visited[md.FullName()] = true
+		v, err := o.validater(md)
+		if err != nil {
+			return fmt.Errorf("message %s error: %w", md.FullName(), err)
+		}
+		constants = append(constants, v.Constants()...)
+		for i := 0; i < md.Fields().Len(); i++ {
+			fd := md.Fields().Get(i)
+			if fd.IsMap() {
+				fd = fd.MapValue()
+			}
+			if fd.Message() != nil {
+				if err := visit(fd.Message()); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := visit(md); err != nil {
+		return nil, err
+	}
+	return constants, nil
+}
+
+// mutator consumes its data to choose the mutations and the values, so that
+// the same data always produces the same message
+type mutator struct {
+	data      []byte
+	constants []ref.Val
+}
+
+func (mu *mutator) next() byte {
+	if len(mu.data) == 0 {
+		return 0
+	}
+	b := mu.data[0]
+	mu.data = mu.data[1:]
+	return b
+}
+
+func (mu *mutator) intn(n int) int {
+	if n <= 1 {
+		return 0
+	}
+	return int(binary.LittleEndian.Uint16([]byte{mu.next(), mu.next()})) % n
+}
+
+func (mu *mutator) uint64() uint64 {
+	b := make([]byte, 8)
+	for i := range b {
+		b[i] = mu.next()
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+// mutate applies one to eight mutations to the fields of the message
+func (mu *mutator) mutate(m protoreflect.Message, depth int) {
+	for n := 1 + mu.intn(8); n > 0; n-- {
+		mu.mutateField(m, depth)
+	}
+}
+
+func (mu *mutator) mutateField(m protoreflect.Message, depth int) {
+	fields := m.Descriptor().Fields()
+	if fields.Len() == 0 {
+		return
+	}
+	fd := fields.Get(mu.intn(fields.Len()))
+	switch {
+	case mu.intn(8) == 0:
+		m.Clear(fd)
+	case fd.IsList():
+		l := m.Mutable(fd).List()
+		if i := mu.intn(l.Len() + 1); i < l.Len() {
+			l.Set(i, mu.value(fd, l.Get(i), depth))
+		} else {
+			l.Append(mu.value(fd, l.NewElement(), depth))
+		}
+	case fd.IsMap():
+		mp := m.Mutable(fd).Map()
+		mp.Set(mu.value(fd.MapKey(), protoreflect.Value{}, depth).MapKey(), mu.value(fd.MapValue(), mp.NewValue(), depth))
+	case fd.Message() != nil:
+		if depth < maxMutationDepth {
+			mu.mutate(m.Mutable(fd).Message(), depth+1)
+		}
+	default:
+		m.Set(fd, mu.value(fd, protoreflect.Value{}, depth))
+	}
+}
+
+// value returns a value of the kind of the field, mutating v for messages
+func (mu *mutator) value(fd protoreflect.FieldDescriptor, v protoreflect.Value, depth int) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if depth < maxMutationDepth {
+			mu.mutate(v.Message(), depth+1)
+		}
+		return v
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(mu.next()&1 == 1)
+	case protoreflect.EnumKind:
+		if values := fd.Enum().Values(); values.Len() > 0 && mu.intn(4) > 0 {
+			return protoreflect.ValueOfEnum(values.Get(mu.intn(values.Len())).Number())
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(mu.integer()))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(mu.integer()))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(mu.integer())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(mu.integer()))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(uint64(mu.integer()))
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(mu.float()))
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(mu.float())
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(strings.ToValidUTF8(string(mu.bytes()), "�"))
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(mu.bytes())
+	}
+	return v
+}
+
+// constant returns one of the constants accepted by the filter
+func (mu *mutator) constant(accept func(c ref.Val) bool) (ref.Val, bool) {
+	candidates := []ref.Val{}
+	for _, c := range mu.constants {
+		if accept(c) {
+			candidates = append(candidates, c)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, false
+	}
+	return candidates[mu.intn(len(candidates))], true
+}
+
+func isNumber(c ref.Val) bool {
+	switch c.(type) {
+	case types.Int, types.Uint, types.Double:
+		return true
+	}
+	return false
+}
+
+func isText(c ref.Val) bool {
+	switch c.(type) {
+	case types.String, types.Bytes:
+		return true
+	}
+	return false
+}
+
+func toInt(c ref.Val) int64 {
+	switch c := c.(type) {
+	case types.Int:
+		return int64(c)
+	case types.Uint:
+		return int64(c)
+	case types.Double:
+		return int64(c)
+	}
+	return 0
+}
+
+// integer returns a constant of the rules or one of its neighbours, a small
+// integer, a boundary or any integer
+func (mu *mutator) integer() int64 {
+	switch mu.intn(4) {
+	case 0:
+		if c, ok := mu.constant(isNumber); ok {
+			return toInt(c) + int64(mu.intn(3)) - 1
+		}
+		return 0
+	case 1:
+		return int64(int8(mu.next()))
+	case 2:
+		boundaries := []int64{0, -1, math.MaxInt32, math.MinInt32, math.MaxUint32, math.MaxInt64, math.MinInt64}
+		return boundaries[mu.intn(len(boundaries))]
+	default:
+		return int64(mu.uint64())
+	}
+}
+
+func (mu *mutator) float() float64 {
+	switch mu.intn(4) {
+	case 0:
+		if c, ok := mu.constant(isNumber); ok {
+			if d, ok := c.(types.Double); ok {
+				return float64(d) + float64(mu.intn(3)-1)
+			}
+			return float64(toInt(c) + int64(mu.intn(3)) - 1)
+		}
+		return 0
+	case 1:
+		return float64(int8(mu.next()))
+	case 2:
+		specials := []float64{0, math.Copysign(0, -1), math.NaN(), math.Inf(1), math.Inf(-1), math.MaxFloat64, math.SmallestNonzeroFloat64}
+		return specials[mu.intn(len(specials))]
+	default:
+		return math.Float64frombits(mu.uint64())
+	}
+}
+
+// bytes returns a string or bytes constant of the rules, possibly combined
+// with data, a text whose size is an integer constant, or data
+func (mu *mutator) bytes() []byte {
+	switch mu.intn(4) {
+	case 0, 1:
+		c, ok := mu.constant(isText)
+		if !ok {
+			break
+		}
+		b := []byte(fmt.Sprint(c.Value()))
+		if raw, ok := c.Value().([]byte); ok {
+			b = append([]byte{}, raw...)
+		}
+		switch mu.intn(4) {
+		case 0:
+			return append(b, mu.raw()...)
+		case 1:
+			return append(mu.raw(), b...)
+		case 2:
+			return bytes.Repeat(b, 1+mu.intn(4))
+		}
+		return b
+	case 2:
+		if c, ok := mu.constant(isNumber); ok {
+			if n := toInt(c) + int64(mu.intn(3)) - 1; n >= 0 && n <= maxMutatedSize {
+				return bytes.Repeat([]byte{'a' + byte(mu.intn(26))}, int(n))
+			}
+		}
+	}
+	return mu.raw()
+}
+
+// raw returns up to 32 bytes of the data
+func (mu *mutator) raw() []byte {
+	b := make([]byte, mu.intn(33))
+	for i := range b {
+		b[i] = mu.next()
+	}
+	return b
+}
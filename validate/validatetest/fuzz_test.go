@@ -0,0 +1,130 @@
+package validatetest
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	testdata "github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"google.golang.org/protobuf/proto"
+)
+
+func newFuzzRuntime(opts ...validate.ManagerOption) *validate.Runtime {
+	return validate.NewRuntime(append([]validate.ManagerOption{validate.WithConfiguration(&validate.Configuration{Rule: &validate.FileRule{MessageRules: map[string]*validate.MessageRule{
+		"testdata.validate.MessageSensitive": {FieldRules: map[string]*validate.FieldRule{
+			"name": {Rule: &validate.Rule{Programs: []*validate.Rule_Program{{Id: "size", Expr: `name.size() < 4`}}}},
+		}},
+		"testdata.validate.MessageSensitiveNested": {FieldRules: map[string]*validate.FieldRule{
+			"label": {Rule: &validate.Rule{Programs: []*validate.Rule_Program{{Id: "magic", Expr: `label != "magic-label"`}}}},
+		}},
+	}}})}, opts...)...)
+}
+
+func TestMutate(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	opt := WithRuntime(newFuzzRuntime())
+	foundConstant := false
+	for i := 0; i < 500; i++ {
+		data := make([]byte, r.Intn(128))
+		r.Read(data)
+		first, second := &testdata.MessageSensitive{}, &testdata.MessageSensitive{}
+		for _, m := range []proto.Message{first, second} {
+			if err := Mutate(m, data, opt); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if !proto.Equal(first, second) {
+			t.Fatalf("want deterministic mutations, got %v and %v", first, second)
+		}
+		if strings.Contains(first.GetNested().GetLabel(), "magic-label") {
+			foundConstant = true
+		}
+	}
+	if !foundConstant {
+		t.Errorf("want a nested label built from the rule constant")
+	}
+}
+
+// generatedMessage has a Validate method, like the messages of the generated
+// code, returning err
+type generatedMessage struct {
+	proto.Message
+	err error
+}
+
+func (m *generatedMessage) Validate(ctx context.Context) error {
+	return m.err
+}
+
+func TestCheckValidation(t *testing.T) {
+	var calls int64
+	flip := validate.ResolverFunc(func(ctx context.Context, name string, args []ref.Val) (ref.Val, error) {
+		return types.Bool(atomic.AddInt64(&calls, 1)%2 == 0), nil
+	})
+	tests := []struct {
+		Name     string
+		Message  proto.Message
+		Opts     []Option
+		WantFail bool
+	}{
+		{
+			Name:    "Generated validation",
+			Message: &testdata.MessageExpr{},
+		},
+		{
+			Name:    "Generated method",
+			Message: &generatedMessage{Message: &testdata.MessageExpr{Name: "name"}},
+		},
+		{
+			Name:     "Generated method divergent",
+			Message:  &generatedMessage{Message: &testdata.MessageExpr{}},
+			WantFail: true,
+		},
+		{
+			Name:     "Generated method divergent from runtime",
+			Message:  &generatedMessage{Message: &testdata.MessageSensitive{Name: "hunter2"}},
+			Opts:     []Option{WithRuntime(newFuzzRuntime())},
+			WantFail: true,
+		},
+		{
+			Name:    "Runtime",
+			Message: &testdata.MessageSensitive{Name: "hunter2"},
+			Opts:    []Option{WithRuntime(newFuzzRuntime())},
+		},
+		{
+			Name:    "Not deterministic",
+			Message: &testdata.MessageSensitive{Name: "name"},
+			Opts: []Option{WithRuntime(validate.NewRuntime(
+				validate.WithConfiguration(&validate.Configuration{Rule: &validate.FileRule{MessageRules: map[string]*validate.MessageRule{
+					"testdata.validate.MessageSensitive": {FieldRules: map[string]*validate.FieldRule{
+						"name": {Rule: &validate.Rule{Programs: []*validate.Rule_Program{{Expr: `flip()`}}}},
+					}},
+				}}}),
+				validate.WithResolver("flip", []*cel.Type{}, cel.BoolType, flip, validate.WithResolverCacheDisabled()),
+			))},
+			WantFail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			if ok := CheckValidation(r, tt.Message, tt.Opts...); ok == tt.WantFail || (len(r.failures) > 0) != tt.WantFail {
+				t.Errorf("want failure %v, got %v", tt.WantFail, r.failures)
+			}
+		})
+	}
+}
+
+func FuzzMessageExpr(f *testing.F) {
+	Fuzz(f, &testdata.MessageExpr{})
+}
+
+func FuzzMessageSensitive(f *testing.F) {
+	Fuzz(f, &testdata.MessageSensitive{Name: "abc"}, WithRuntime(newFuzzRuntime()))
+}
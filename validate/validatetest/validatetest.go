@@ -0,0 +1,223 @@
+// Package validatetest provides helpers to assert the outcome of the
+// validation rules in tests, and to run table-driven cases from fixtures.
+package validatetest
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"gopkg.in/yaml.v3"
+)
+
+type Option interface {
+	apply(o *options)
+}
+
+type options struct {
+	ctx     context.Context
+	runtime *validate.Runtime
+}
+
+type option func(o *options)
+
+func (opt option) apply(o *options) { opt(o) }
+
+// WithContext sets the context of the validations
+func WithContext(ctx context.Context) Option {
+	return option(func(o *options) {
+		o.ctx = ctx
+	})
+}
+
+// WithRuntime validates the messages with the runtime, instead of their
+// generated validation
+func WithRuntime(r *validate.Runtime) Option {
+	return option(func(o *options) {
+		o.runtime = r
+	})
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{ctx: context.Background()}
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+	return o
+}
+
+// Validate validates the message with the runtime if set, with its generated
+// validation, or with validate.Message otherwise
+func Validate(m proto.Message, opts ...Option) error {
+	o := newOptions(opts)
+	if o.runtime != nil {
+		return o.runtime.Validate(o.ctx, m)
+	} else if v, ok := m.(interface {
+		Validate(ctx context.Context) error
+	}); ok {
+		return v.Validate(o.ctx)
+	}
+	return validate.Message(o.ctx, m)
+}
+
+// ViolationOf returns the program and the field path of a validation error
+func ViolationOf(err error) (*Violation, bool) {
+	var vErr errors.ValidateError
+	if !stderrors.As(err, &vErr) {
+		return nil, false
+	}
+	v := &Violation{ProgramId: vErr.GetProgramId()}
+	path := []string{}
+	for vErr != nil {
+		if fd, ok := vErr.GetDescriptor().(protoreflect.FieldDescriptor); ok {
+			path = append(path, string(fd.Name()))
+		}
+		var next errors.ValidateError
+		if !stderrors.As(vErr.Unwrap(), &next) {
+			break
+		}
+		vErr = next
+	}
+	v.Field = strings.Join(path, ".")
+	return v, true
+}
+
+// AssertValid reports an error if the message is not valid
+func AssertValid(t testing.TB, m proto.Message, opts ...Option) bool {
+	t.Helper()
+	if err := Validate(m, opts...); err != nil {
+		t.Errorf("want valid %s, got %s", m.ProtoReflect().Descriptor().FullName(), describe(err))
+		return false
+	}
+	return true
+}
+
+// AssertInvalid reports an error unless the validation of the message fails
+// with the violation, or with any error if want is nil. The empty fields of
+// want match any program id or field.
+func AssertInvalid(t testing.TB, m proto.Message, want *Violation, opts ...Option) bool {
+	t.Helper()
+	err := Validate(m, opts...)
+	if err == nil {
+		t.Errorf("want invalid %s, got valid", m.ProtoReflect().Descriptor().FullName())
+		return false
+	}
+	if want == nil {
+		return true
+	}
+	if got, ok := ViolationOf(err); !ok || !want.matches(got) {
+		t.Errorf("want violation of program %q on field %q, got %s", want.GetProgramId(), want.GetField(), describe(err))
+		return false
+	}
+	return true
+}
+
+// matches reports whether the violation is the expected one, the empty fields
+// of v being wildcards
+func (v *Violation) matches(got *Violation) bool {
+	return (v.GetProgramId() == "" || v.GetProgramId() == got.GetProgramId()) && (v.GetField() == "" || v.GetField() == got.GetField())
+}
+
+func describe(err error) string {
+	v, ok := ViolationOf(err)
+	if !ok {
+		return err.Error()
+	}
+	text := fmt.Sprintf("violation of program %q on field %q: %v", v.ProgramId, v.Field, err)
+	var vErr errors.ValidateError
+	if stderrors.As(err, &vErr) && vErr.GetExplanation() != nil {
+		text += "\n" + vErr.GetExplanation().String()
+	}
+	return text
+}
+
+// LoadFixture reads a fixture, in YAML or JSON according to the extension of
+// the file, in the protobuf text format otherwise
+func LoadFixture(path string) (*Fixture, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f := &Fixture{}
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		var raw interface{}
+		if err = yaml.Unmarshal(b, &raw); err == nil {
+			if b, err = json.Marshal(raw); err == nil {
+				err = protojson.Unmarshal(b, f)
+			}
+		}
+	case ".json":
+		err = protojson.Unmarshal(b, f)
+	default:
+		err = prototext.Unmarshal(b, f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fixture %s error: %w", path, err)
+	}
+	return f, nil
+}
+
+// RunFixture runs the cases of the fixture file as subtests
+func RunFixture(t *testing.T, path string, opts ...Option) {
+	t.Helper()
+	f, err := LoadFixture(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Run(t, f, opts...)
+}
+
+// Run runs the cases of the fixture as subtests
+func Run(t *testing.T, f *Fixture, opts ...Option) {
+	t.Helper()
+	o := newOptions(opts)
+	for _, c := range f.GetCases() {
+		c := c
+		t.Run(c.GetName(), func(t *testing.T) {
+			t.Helper()
+			m, err := o.newMessage(f.GetType(), c.GetMessage())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.Violation == nil {
+				AssertValid(t, m, opts...)
+			} else {
+				AssertInvalid(t, m, c.Violation, opts...)
+			}
+		})
+	}
+}
+
+// newMessage parses the message of the type, resolved by the runtime if set
+func (o *options) newMessage(name string, text string) (proto.Message, error) {
+	var resolver validate.TypeResolver = protoregistry.GlobalTypes
+	var m proto.Message
+	if o.runtime != nil {
+		var err error
+		if m, err = o.runtime.NewMessage(name); err != nil {
+			return nil, err
+		}
+		resolver = o.runtime.TypeResolver()
+	} else if mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(name)); err != nil {
+		return nil, fmt.Errorf("message %s error: %w", name, err)
+	} else {
+		m = mt.New().Interface()
+	}
+	if err := (prototext.UnmarshalOptions{Resolver: resolver}).Unmarshal([]byte(text), m); err != nil {
+		return nil, fmt.Errorf("message %s error: %w", name, err)
+	}
+	return m, nil
+}
@@ -0,0 +1,184 @@
+package validatetest
+
+import (
+	"fmt"
+	"testing"
+
+	testdata "github.com/nlachfr/protoc-gen-cel-validate/testdata/validate"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate"
+	"github.com/nlachfr/protoc-gen-cel-validate/validate/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func newRuntime() *validate.Runtime {
+	return validate.NewRuntime(validate.WithConfiguration(&validate.Configuration{Rule: &validate.FileRule{MessageRules: map[string]*validate.MessageRule{
+		"testdata.validate.MessageSensitive": {FieldRules: map[string]*validate.FieldRule{
+			"name": {Rule: &validate.Rule{Programs: []*validate.Rule_Program{{Id: "size", Expr: `name.size() < 4`}}}},
+		}},
+	}}}))
+}
+
+func TestViolationOf(t *testing.T) {
+	desc := testdata.File_testdata_validate_message_proto.Messages().ByName("MessageSensitive")
+	nestedDesc := testdata.File_testdata_validate_message_proto.Messages().ByName("MessageSensitiveNested")
+	tests := []struct {
+		Name   string
+		Err    error
+		Want   *Violation
+		WantOk bool
+	}{
+		{
+			Name: "Not a validation error",
+			Err:  fmt.Errorf("required failed"),
+		},
+		{
+			Name:   "Message program",
+			Err:    errors.WithProgram(errors.New(nil, desc, nil), "id", "true"),
+			Want:   &Violation{ProgramId: "id"},
+			WantOk: true,
+		},
+		{
+			Name:   "Nested field program",
+			Err:    errors.Wrap(errors.WithProgram(errors.New(nil, nestedDesc.Fields().ByName("secret"), nil), "id", "true"), nil, desc.Fields().ByName("nested"), nil),
+			Want:   &Violation{ProgramId: "id", Field: "nested.secret"},
+			WantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			got, ok := ViolationOf(tt.Err)
+			if ok != tt.WantOk || !proto.Equal(got, tt.Want) {
+				t.Errorf("want %v (%v), got %v (%v)", tt.Want, tt.WantOk, got, ok)
+			}
+		})
+	}
+}
+
+func TestAssert(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Message   proto.Message
+		Valid     bool
+		Violation *Violation
+		Opts      []Option
+		WantFail  bool
+	}{
+		{
+			Name:    "Valid",
+			Message: &testdata.MessageExpr{Name: "name"},
+			Valid:   true,
+		},
+		{
+			Name:     "Not valid",
+			Message:  &testdata.MessageExpr{},
+			Valid:    true,
+			WantFail: true,
+		},
+		{
+			Name:      "Violation",
+			Message:   &testdata.MessageExpr{},
+			Violation: &Violation{},
+		},
+		{
+			Name:     "Valid instead of violation",
+			Message:  &testdata.MessageExpr{Name: "name"},
+			WantFail: true,
+		},
+		{
+			Name:      "Field violation with runtime",
+			Message:   &testdata.MessageSensitive{Name: "hunter2"},
+			Violation: &Violation{ProgramId: "size", Field: "name"},
+			Opts:      []Option{WithRuntime(newRuntime())},
+		},
+		{
+			Name:      "Any program of the field",
+			Message:   &testdata.MessageSensitive{Name: "hunter2"},
+			Violation: &Violation{Field: "name"},
+			Opts:      []Option{WithRuntime(newRuntime())},
+		},
+		{
+			Name:      "Program on any field",
+			Message:   &testdata.MessageSensitive{Name: "hunter2"},
+			Violation: &Violation{ProgramId: "size"},
+			Opts:      []Option{WithRuntime(newRuntime())},
+		},
+		{
+			Name:      "Any program of another field",
+			Message:   &testdata.MessageSensitive{Name: "hunter2"},
+			Violation: &Violation{Field: "nested.label"},
+			Opts:      []Option{WithRuntime(newRuntime())},
+			WantFail:  true,
+		},
+		{
+			Name:      "Other violation",
+			Message:   &testdata.MessageSensitive{Name: "hunter2"},
+			Violation: &Violation{ProgramId: "other", Field: "name"},
+			Opts:      []Option{WithRuntime(newRuntime())},
+			WantFail:  true,
+		},
+		{
+			Name:    "Generated validation",
+			Message: &testdata.TestRpcRequest{},
+		},
+		{
+			Name:      "Generated validation without violation",
+			Message:   &testdata.TestRpcRequest{},
+			Violation: &Violation{},
+			WantFail:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			var ok bool
+			if tt.Valid {
+				ok = AssertValid(r, tt.Message, tt.Opts...)
+			} else {
+				ok = AssertInvalid(r, tt.Message, tt.Violation, tt.Opts...)
+			}
+			if ok == tt.WantFail || (len(r.failures) > 0) != tt.WantFail {
+				t.Errorf("want failure %v, got %v", tt.WantFail, r.failures)
+			}
+		})
+	}
+}
+
+func TestRunFixture(t *testing.T) {
+	t.Run("Text format", func(t *testing.T) {
+		RunFixture(t, "../../testdata/validatetest/message.textproto")
+	})
+	t.Run("YAML", func(t *testing.T) {
+		RunFixture(t, "../../testdata/validatetest/message.yaml", WithRuntime(newRuntime()))
+	})
+}
+
+func TestLoadFixture(t *testing.T) {
+	f, err := LoadFixture("../../testdata/validatetest/message.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &Fixture{
+		Type: "testdata.validate.MessageSensitive",
+		Cases: []*Case{
+			{Name: "valid", Message: `name: "abc"`},
+			{Name: "name too long", Message: "name: \"hunter2\"\nnested { label: \"label\" }\n", Violation: &Violation{ProgramId: "size", Field: "name"}},
+		},
+	}
+	if !proto.Equal(f, want) {
+		t.Errorf("want %v, got %v", want, f)
+	}
+	if _, err := LoadFixture("../../testdata/validatetest/missing.textproto"); err == nil {
+		t.Errorf("want error")
+	}
+}